@@ -0,0 +1,101 @@
+// Package api defines types of ingress-dashboard JSON API. They are shared by server and Go client.
+package api
+
+import (
+	"time"
+)
+
+// TLSStatus is summarized state of TLS.
+type TLSStatus string
+
+const (
+	TLSDisabled   TLSStatus = "disabled"    // TLS not enabled
+	TLSUnknown    TLSStatus = "unknown"     // TLS enabled, but certificate not yet checked
+	TLSExpired    TLSStatus = "expired"     // certificate expired
+	TLSSoonExpire TLSStatus = "soon-expire" // certificate expires in less than 2 weeks
+	TLSValid      TLSStatus = "valid"       // certificate valid
+)
+
+// Ingress is dashboard entry: Ingress object, dashboard entry or static definition.
+type Ingress struct {
+	UID         string    `json:"uid"`                   // machine readable ID
+	ID          string    `json:"id"`                    // human readable ID (namespace with name)
+	Name        string    `json:"name"`                  // object name
+	Namespace   string    `json:"namespace"`             // object namespace
+	Title       string    `json:"title,omitempty"`       // custom title
+	Label       string    `json:"label"`                 // title if defined, otherwise name
+	Description string    `json:"description,omitempty"` // human-readable description
+	LogoURL     string    `json:"logo_url,omitempty"`    // absolute or relative (to the server) logo URL
+	Class       string    `json:"class,omitempty"`       // ingress class
+	Tags        []string  `json:"tags,omitempty"`        // list of tags
+	Alias       string    `json:"alias,omitempty"`       // short name for /go/ links, if defined
+	Static      bool      `json:"static"`                // defined by static source or dashboard entry
+	TLS         bool      `json:"tls"`                   // TLS enabled
+	TLSStatus   TLSStatus `json:"tls_status"`            // summarized TLS state
+	DeadRefs    bool      `json:"dead_refs"`             // at least one reference has no hosts
+	Refs        []Ref     `json:"refs"`                  // references (links)
+	Cert        *Cert     `json:"cert,omitempty"`        // TLS certificate info, nil if not yet checked
+}
+
+type Ref struct {
+	URL    string `json:"url"`    // link
+	Pods   int    `json:"pods"`   // number of hosts linked to the service
+	Static bool   `json:"static"` // static reference (pods number has no sense)
+	Dead   bool   `json:"dead"`   // no hosts for non-static reference
+}
+
+type Cert struct {
+	Expiration time.Time `json:"expiration"` // certificate expiration
+	ExpiresIn  int64     `json:"expires_in"` // seconds till expiration, negative if expired
+	Domains    []string  `json:"domains"`    // subjects
+	Issuer     string    `json:"issuer"`     // issuer common name
+}
+
+type Namespace struct {
+	Name      string `json:"name"`      // namespace name
+	Ingresses int    `json:"ingresses"` // number of visible entries
+}
+
+type IngressList struct {
+	Items []Ingress `json:"items"`
+}
+
+type NamespaceList struct {
+	Items []Namespace `json:"items"`
+}
+
+// Error returned by API.
+type Error struct {
+	StatusCode int    `json:"-"`
+	Message    string `json:"error"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// EventType of catalog change.
+type EventType string
+
+const (
+	EventAdded   EventType = "added"   // entry appeared
+	EventUpdated EventType = "updated" // entry changed
+	EventRemoved EventType = "removed" // entry disappeared (removed or hidden)
+	EventReset   EventType = "reset"   // requested events are no longer available, full state should be re-fetched
+)
+
+// Event of catalog change, sent by server-sent events stream.
+type Event struct {
+	ID      uint64    `json:"id"`                // sequence number, used for resuming
+	Type    EventType `json:"type"`              // type of change
+	Time    time.Time `json:"time"`              // time of change detection
+	Ingress *Ingress  `json:"ingress,omitempty"` // current state (last known state for removed)
+	Changes []Change  `json:"changes,omitempty"` // changed fields for updated entry
+}
+
+// Change of a single field.
+type Change struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
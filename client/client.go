@@ -0,0 +1,127 @@
+// Package client provides typed client for ingress-dashboard JSON API.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// New client for dashboard located at baseURL (ex: https://dashboard.example.com).
+func New(baseURL string) *Client {
+	return &Client{
+		BaseURL: strings.TrimRight(baseURL, "/"),
+		HTTP:    http.DefaultClient,
+	}
+}
+
+type Client struct {
+	BaseURL  string
+	HTTP     *http.Client
+	Username string // optional, for basic authorization
+	Password string // optional, for basic authorization
+}
+
+// ListOptions filters entries. Empty fields are ignored. Multiple values of the same field are combined by OR,
+// different fields are combined by AND.
+type ListOptions struct {
+	Namespaces []string    // exact namespace
+	Classes    []string    // exact ingress class
+	Tags       []string    // exact tag
+	TLS        []TLSStatus // TLS status
+	Dead       *bool       // has (or not) references without hosts
+	Query      string      // case-insensitive text in title, name, namespace, description, URLs or tags
+}
+
+func (opts ListOptions) values() url.Values {
+	var values = make(url.Values)
+	values["namespace"] = opts.Namespaces
+	values["class"] = opts.Classes
+	values["tag"] = opts.Tags
+	for _, status := range opts.TLS {
+		values.Add("tls", string(status))
+	}
+	if opts.Dead != nil {
+		values.Set("dead", strconv.FormatBool(*opts.Dead))
+	}
+	if opts.Query != "" {
+		values.Set("q", opts.Query)
+	}
+
+	return values
+}
+
+// ListIngresses returns visible entries matched by options.
+func (c *Client) ListIngresses(ctx context.Context, opts ListOptions) ([]Ingress, error) {
+	var list IngressList
+	err := c.get(ctx, "/api/v1/ingresses", opts.values(), &list)
+
+	return list.Items, err
+}
+
+// GetIngress by UID.
+func (c *Client) GetIngress(ctx context.Context, uid string) (*Ingress, error) {
+	var ingress Ingress
+	err := c.get(ctx, "/api/v1/ingresses/"+url.PathEscape(uid), nil, &ingress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ingress, nil
+}
+
+// ListNamespaces returns namespaces with number of visible entries.
+func (c *Client) ListNamespaces(ctx context.Context) ([]Namespace, error) {
+	var list NamespaceList
+	err := c.get(ctx, "/api/v1/namespaces", nil, &list)
+
+	return list.Items, err
+}
+
+// IsNotFound checks that error caused by missed entry.
+func IsNotFound(err error) bool {
+	var apiErr *Error
+
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+func (c *Client) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	u := c.BaseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.Username != "" || c.Password != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+
+	res, err := c.HTTP.Do(req)
+	if err != nil {
+		return fmt.Errorf("execute request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		var apiErr = &Error{StatusCode: res.StatusCode}
+		if err := json.NewDecoder(res.Body).Decode(apiErr); err != nil || apiErr.Message == "" {
+			apiErr.Message = res.Status
+		}
+
+		return apiErr
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package client_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/reddec/ingress-dashboard/client"
+	"github.com/reddec/ingress-dashboard/internal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	svc := internal.New()
+	svc.Prepend(nil)
+	svc.Set([]internal.Ingress{{
+		ID:        "default.grafana",
+		UID:       "uid-grafana",
+		Name:      "grafana",
+		Namespace: "default",
+		Tags:      []string{"monitoring"},
+		TLS:       true,
+		Cert:      internal.CertInfo{Expiration: time.Now().Add(time.Hour), Domains: []string{"grafana.example.com"}},
+		Refs:      []internal.Ref{{URL: "https://grafana.example.com/", Pods: 1}},
+	}, {
+		ID:        "monitoring.prometheus",
+		UID:       "uid-prometheus",
+		Name:      "prometheus",
+		Namespace: "monitoring",
+		Refs:      []internal.Ref{{URL: "http://prometheus.example.com/"}},
+	}})
+
+	server := httptest.NewServer(svc)
+	defer server.Close()
+
+	ctx := context.Background()
+	api := client.New(server.URL + "/")
+
+	list, err := api.ListIngresses(ctx, client.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+
+	dead := true
+	list, err = api.ListIngresses(ctx, client.ListOptions{Dead: &dead})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, "uid-prometheus", list[0].UID)
+
+	list, err = api.ListIngresses(ctx, client.ListOptions{TLS: []client.TLSStatus{client.TLSSoonExpire}, Tags: []string{"monitoring"}})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, "uid-grafana", list[0].UID)
+	require.NotNil(t, list[0].Cert)
+	require.Equal(t, []string{"grafana.example.com"}, list[0].Cert.Domains)
+
+	ingress, err := api.GetIngress(ctx, "uid-grafana")
+	require.NoError(t, err)
+	require.Equal(t, "grafana", ingress.Label)
+
+	_, err = api.GetIngress(ctx, "unknown")
+	require.Error(t, err)
+	require.True(t, client.IsNotFound(err))
+
+	_, err = api.ListIngresses(ctx, client.ListOptions{TLS: []client.TLSStatus{"broken"}})
+	require.Error(t, err)
+	require.False(t, client.IsNotFound(err))
+
+	namespaces, err := api.ListNamespaces(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []client.Namespace{{Name: "default", Ingresses: 1}, {Name: "monitoring", Ingresses: 1}}, namespaces)
+}
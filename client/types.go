@@ -0,0 +1,35 @@
+package client
+
+import (
+	"github.com/reddec/ingress-dashboard/api"
+)
+
+// Types of API are defined in package api. Aliases are kept, so client could be used without importing api.
+type (
+	TLSStatus     = api.TLSStatus
+	Ingress       = api.Ingress
+	Ref           = api.Ref
+	Cert          = api.Cert
+	Namespace     = api.Namespace
+	IngressList   = api.IngressList
+	NamespaceList = api.NamespaceList
+	Error         = api.Error
+	EventType     = api.EventType
+	Event         = api.Event
+	Change        = api.Change
+)
+
+const (
+	TLSDisabled   = api.TLSDisabled
+	TLSUnknown    = api.TLSUnknown
+	TLSExpired    = api.TLSExpired
+	TLSSoonExpire = api.TLSSoonExpire
+	TLSValid      = api.TLSValid
+)
+
+const (
+	EventAdded   = api.EventAdded
+	EventUpdated = api.EventUpdated
+	EventRemoved = api.EventRemoved
+	EventReset   = api.EventReset
+)
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/reddec/ingress-dashboard/internal"
+)
+
+type ExportCmd struct {
+	Hidden bool   `long:"hidden" env:"HIDDEN" description:"Include hidden entries"`
+	Output string `short:"o" long:"output" env:"OUTPUT" description:"Output file, stdout if not set"`
+	config *Config
+}
+
+func (cmd *ExportCmd) Execute([]string) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	cfg := cmd.config
+	clientset, dynamicClient, err := cfg.clients()
+	if err != nil {
+		return err
+	}
+
+	list, err := internal.LoadDefinitions(cfg.StaticSource, cfg.StaticVars)
+	if err != nil {
+		return fmt.Errorf("load static definitions: %w", err)
+	}
+
+	if cfg.Entries {
+		entries, err := internal.ListDashboardEntries(ctx, dynamicClient)
+		if err != nil {
+			return err
+		}
+		list = append(list, entries...)
+	}
+
+	ingresses, err := internal.ListKubernetes(ctx, clientset)
+	if err != nil {
+		return err
+	}
+	list = append(list, ingresses...)
+
+	if !cmd.Hidden {
+		var visible = make([]internal.Ingress, 0, len(list))
+		for _, ing := range list {
+			if !ing.Hide {
+				visible = append(visible, ing)
+			}
+		}
+		list = visible
+	}
+
+	return writeDefinitions(cmd.Output, list)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/reddec/ingress-dashboard/internal"
+	"github.com/reddec/ingress-dashboard/internal/importer"
+)
+
+type ImportCmd struct {
+	Format    string `short:"f" long:"format" env:"FORMAT" description:"Source format" required:"true" choice:"homer" choice:"homepage" choice:"heimdall"`
+	BaseURL   string `short:"b" long:"base-url" env:"BASE_URL" description:"URL of source dashboard to resolve relative icons"`
+	Namespace string `short:"n" long:"namespace" env:"NAMESPACE" description:"Namespace for entries without group"`
+	Output    string `short:"o" long:"output" env:"OUTPUT" description:"Output file, stdout if not set"`
+	Args      struct {
+		Source string `positional-arg-name:"source" description:"Source file, stdin if not set"`
+	} `positional-args:"yes"`
+}
+
+func (cmd *ImportCmd) Execute([]string) error {
+	var input io.Reader = os.Stdin
+	if cmd.Args.Source != "" {
+		f, err := os.Open(cmd.Args.Source)
+		if err != nil {
+			return fmt.Errorf("open source: %w", err)
+		}
+		defer f.Close()
+		input = f
+	}
+
+	list, err := importer.Import(importer.Format(cmd.Format), input, importer.Options{
+		BaseURL:   cmd.BaseURL,
+		Namespace: cmd.Namespace,
+	})
+	if err != nil {
+		return fmt.Errorf("import: %w", err)
+	}
+
+	return writeDefinitions(cmd.Output, list)
+}
+
+func writeDefinitions(output string, list []internal.Ingress) error {
+	if output == "" {
+		return internal.WriteDefinitions(os.Stdout, list)
+	}
+	f, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("create output: %w", err)
+	}
+	defer f.Close()
+	if err := internal.WriteDefinitions(f, list); err != nil {
+		return fmt.Errorf("write definitions: %w", err)
+	}
+
+	return f.Close()
+}
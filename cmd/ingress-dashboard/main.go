@@ -7,11 +7,14 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 
 	"github.com/jessevdk/go-flags"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/reddec/ingress-dashboard/internal"
 	"github.com/reddec/ingress-dashboard/internal/auth"
 	httpserver "github.com/reddec/run-http-server"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 )
@@ -26,16 +29,27 @@ var (
 
 type Config struct {
 	httpserver.Server
-	Master        string `long:"master" env:"MASTER" description:"Kuberenetes master URL"`
-	Kubeconfig    string `long:"kubeconfig" env:"KUBECONFIG" description:"Path to kubeconfig for local setup"`
-	OIDCIssuer    string `long:"oidc-issuer" env:"OIDC_ISSUER" description:"OIDC issuer URL"`
-	ClientID      string `long:"client-id" env:"CLIENT_ID" description:"OAuth client ID"`
-	ClientSecret  string `long:"client-secret" env:"CLIENT_SECRET" description:"OAuth client secret"`
-	ServerURL     string `long:"server-url" env:"SERVER_URL" description:"Server URL used for OAuth redirects"`
-	Auth          string `long:"auth" env:"AUTH" description:"Auth scheme" default:"none" choice:"none" choice:"oidc" choice:"basic"`
-	BasicUser     string `long:"basic-user" env:"BASIC_USER" description:"Basic Auth username"`
-	BasicPassword string `long:"basic-password" env:"BASIC_PASSWORD" description:"Basic Auth password"`
-	StaticSource  string `long:"static-source" env:"STATIC_SOURCE" description:"Location of static ingress definitions" `
+	Master        string            `long:"master" env:"MASTER" description:"Kuberenetes master URL"`
+	Kubeconfig    string            `long:"kubeconfig" env:"KUBECONFIG" description:"Path to kubeconfig for local setup"`
+	OIDCIssuer    string            `long:"oidc-issuer" env:"OIDC_ISSUER" description:"OIDC issuer URL"`
+	ClientID      string            `long:"client-id" env:"CLIENT_ID" description:"OAuth client ID"`
+	ClientSecret  string            `long:"client-secret" env:"CLIENT_SECRET" description:"OAuth client secret"`
+	ServerURL     string            `long:"server-url" env:"SERVER_URL" description:"Server URL used for OAuth redirects"`
+	Auth          string            `long:"auth" env:"AUTH" description:"Auth scheme" default:"none" choice:"none" choice:"oidc" choice:"basic"`
+	BasicUser     string            `long:"basic-user" env:"BASIC_USER" description:"Basic Auth username"`
+	BasicPassword string            `long:"basic-password" env:"BASIC_PASSWORD" description:"Basic Auth password"`
+	StaticSource  string            `long:"static-source" env:"STATIC_SOURCE" description:"Location of static ingress definitions" `
+	StaticVars    map[string]string `long:"static-var" env:"STATIC_VARS" env-delim:"," key-value-delimiter:"=" description:"Variables for static definitions in format key=value, environment variables used as fallback"`
+	Entries       bool              `long:"dashboard-entries" env:"DASHBOARD_ENTRIES" description:"Watch DashboardEntry custom resources (CRD should be installed)"`
+	MetricsBind   string            `long:"metrics-bind" env:"METRICS_BIND" description:"Binding address for separate metrics listener without auth"`
+	Changelog     string            `long:"changelog" env:"CHANGELOG" description:"Location of file to persist log of catalog changes (Atom feed)"`
+	TemplatesDir  string            `long:"templates-dir" env:"TEMPLATES_DIR" description:"Directory with templates overriding embedded templates"`
+	AssetsDir     string            `long:"assets-dir" env:"ASSETS_DIR" description:"Directory with static files overriding embedded files"`
+	BrandingFile  string            `long:"branding" env:"BRANDING" description:"Location of YAML file with branding, flags take precedence"`
+	Branding      internal.Branding `group:"Branding" namespace:"brand" env-namespace:"BRAND"`
+	Favourites    string            `long:"favourites-file" env:"FAVOURITES_FILE" description:"Location of file to persist favourites of users"`
+	FavouritesCM  string            `long:"favourites-configmap" env:"FAVOURITES_CONFIGMAP" description:"ConfigMap (namespace/name) to persist favourites of users, takes precedence over file"`
+	WallToken     string            `long:"wall-token" env:"WALL_TOKEN" description:"Access token for wall display (/wall?token=...) without login"`
 }
 
 func main() {
@@ -43,33 +57,72 @@ func main() {
 	parser := flags.NewParser(&config, flags.Default)
 	parser.ShortDescription = "Kubernetes-native dashboard for ingress"
 	parser.LongDescription = fmt.Sprintf("Kubernetes-native dashboard for ingress\ningress-dashboard %s, commit %s, built at %s by %s\nAuthor: Aleksandr Baryshnikov <owner@reddec.net>", version, commit, date, builtBy)
+	parser.SubcommandsOptional = true
+	if _, err := parser.AddCommand("import", "Import catalog", "Convert catalog of Homer, Homepage or Heimdall to static definitions", &ImportCmd{}); err != nil {
+		log.Panic(err)
+	}
+	if _, err := parser.AddCommand("export", "Export catalog", "Export Ingress objects, dashboard entries and static definitions as static definitions", &ExportCmd{config: &config}); err != nil {
+		log.Panic(err)
+	}
 
 	if _, err := parser.Parse(); err != nil {
 		os.Exit(1)
 	}
+	if parser.Active != nil {
+		// command already executed
+		return
+	}
 	if err := run(config); err != nil {
 		log.Panic(err)
 	}
 }
 
 func run(cfg Config) error {
-	config, err := clientcmd.BuildConfigFromFlags(cfg.Master, cfg.Kubeconfig)
+	clientset, dynamicClient, err := cfg.clients()
 	if err != nil {
-		return fmt.Errorf("get kube config: %w", err)
-	}
-	clientset, err := kubernetes.NewForConfig(config)
-	if err != nil {
-		return fmt.Errorf("create client: %w", err)
+		return err
 	}
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer cancel()
 
-	svc := internal.New()
-	staticDefinitions, err := internal.LoadDefinitions(cfg.StaticSource)
+	branding, err := internal.LoadBranding(cfg.BrandingFile, cfg.Branding)
+	if err != nil {
+		return fmt.Errorf("load branding: %w", err)
+	}
+
+	svc, err := internal.NewWithOptions(internal.Options{
+		TemplatesDir: cfg.TemplatesDir,
+		AssetsDir:    cfg.AssetsDir,
+		Branding:     branding,
+	})
+	if err != nil {
+		return fmt.Errorf("create service: %w", err)
+	}
+	prometheus.MustRegister(svc)
+
+	if cfg.Changelog != "" {
+		if err := svc.LoadChangelog(cfg.Changelog); err != nil {
+			return fmt.Errorf("load changelog: %w", err)
+		}
+	}
+
+	if err := cfg.setupFavourites(svc, clientset); err != nil {
+		return fmt.Errorf("setup favourites: %w", err)
+	}
+
+	health := svc.Health()
+	health.Register(internal.SourceStatic)
+	health.Register(internal.SourceIngresses)
+	if cfg.Entries {
+		health.Register(internal.SourceEntries)
+	}
+
+	staticDefinitions, err := internal.LoadDefinitions(cfg.StaticSource, cfg.StaticVars)
 	if err != nil {
 		return fmt.Errorf("load static definitions: %w", err)
 	}
 	svc.Prepend(staticDefinitions)
+	health.Synced(internal.SourceStatic)
 
 	secured, err := cfg.secureHandler(ctx, svc)
 	if err != nil {
@@ -78,15 +131,95 @@ func run(cfg Config) error {
 
 	go func() {
 		defer cancel()
-		internal.WatchKubernetes(ctx, clientset, svc)
+		internal.WatchKubernetes(ctx, clientset, svc, health)
+	}()
+
+	go func() {
+		defer cancel()
+		internal.WatchNamespaces(ctx, clientset, svc)
+	}()
+
+	go func() {
+		defer cancel()
+		internal.WatchStatic(ctx, staticDefinitions, internal.ReceiverFunc(svc.Prepend))
 	}()
 
+	if cfg.Entries {
+		go func() {
+			defer cancel()
+			internal.WatchDashboardEntries(ctx, dynamicClient, internal.ReceiverFunc(svc.SetCustom), health)
+		}()
+	}
+
+	if cfg.MetricsBind != "" {
+		go func() {
+			defer cancel()
+			if err := cfg.runMetrics(ctx); err != nil {
+				log.Println("metrics server stopped:", err)
+			}
+		}()
+	}
+
 	http.Handle("/", secured)
+	http.Handle("/wall", auth.NewToken(cfg.WallToken, svc, secured)) // TV screens can't pass OIDC login
+	http.Handle("/badge/", svc.PublicBadges(secured))                // badges of public entries are embedded in README files
 	http.Handle("/favicon.ico", svc)
+	http.Handle("/healthz", svc) // probes should not require auth
+	http.Handle("/readyz", svc)
 
 	return cfg.Run(ctx)
 }
 
+func (cfg Config) runMetrics(ctx context.Context) error {
+	var mux = http.NewServeMux()
+	mux.Handle("/metrics", internal.MetricsHandler())
+	server := httpserver.Server{
+		Bind:             cfg.MetricsBind,
+		GracefulShutdown: cfg.GracefulShutdown,
+	}
+
+	return server.Serve(ctx, &http.Server{ //nolint:gosec
+		Addr:    cfg.MetricsBind,
+		Handler: mux,
+	})
+}
+
+func (cfg Config) clients() (*kubernetes.Clientset, dynamic.Interface, error) {
+	config, err := clientcmd.BuildConfigFromFlags(cfg.Master, cfg.Kubeconfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get kube config: %w", err)
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create client: %w", err)
+	}
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create dynamic client: %w", err)
+	}
+
+	return clientset, dynamicClient, nil
+}
+
+func (cfg Config) setupFavourites(svc *internal.Service, clientset kubernetes.Interface) error {
+	switch {
+	case cfg.FavouritesCM != "":
+		sep := strings.Index(cfg.FavouritesCM, "/")
+		if sep <= 0 || sep == len(cfg.FavouritesCM)-1 {
+			return fmt.Errorf("ConfigMap should be in format namespace/name: %s", cfg.FavouritesCM) //nolint:goerr113
+		}
+		svc.SetFavouritesStore(internal.NewConfigMapFavourites(clientset, cfg.FavouritesCM[:sep], cfg.FavouritesCM[sep+1:]))
+	case cfg.Favourites != "":
+		store, err := internal.NewFileFavourites(cfg.Favourites)
+		if err != nil {
+			return err
+		}
+		svc.SetFavouritesStore(store)
+	}
+
+	return nil
+}
+
 func (cfg Config) secureHandler(ctx context.Context, handler http.Handler) (http.Handler, error) {
 	switch cfg.Auth {
 	case "none":
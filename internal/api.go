@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/reddec/ingress-dashboard/api"
+)
+
+func (svc *Service) apiListIngresses(writer http.ResponseWriter, request *http.Request, _ httprouter.Params) {
+	filter := ParseFilter(request.URL.Query())
+	for _, status := range filter.TLS {
+		switch status {
+		case TLSDisabled, TLSUnknown, TLSExpired, TLSSoonExpire, TLSValid:
+		default:
+			writeJSON(writer, http.StatusBadRequest, api.Error{Message: "unknown TLS status " + string(status)})
+
+			return
+		}
+	}
+
+	list := filter.Search(visibleIngresses(svc.getList()))
+	var ans = api.IngressList{Items: make([]api.Ingress, 0, len(list))}
+	for _, ing := range list {
+		ans.Items = append(ans.Items, toAPIIngress(ing))
+	}
+	writeJSON(writer, http.StatusOK, ans)
+}
+
+func (svc *Service) apiGetIngress(writer http.ResponseWriter, _ *http.Request, params httprouter.Params) {
+	uid := params.ByName("uid")
+	for _, ing := range visibleIngresses(svc.getList()) {
+		if ing.UID == uid {
+			writeJSON(writer, http.StatusOK, toAPIIngress(ing))
+
+			return
+		}
+	}
+	writeJSON(writer, http.StatusNotFound, api.Error{Message: "ingress not found"})
+}
+
+func (svc *Service) apiListNamespaces(writer http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	var counts = make(map[string]int)
+	for _, ing := range visibleIngresses(svc.getList()) {
+		counts[ing.Namespace]++
+	}
+	var ans = api.NamespaceList{Items: make([]api.Namespace, 0, len(counts))}
+	for name, count := range counts {
+		ans.Items = append(ans.Items, api.Namespace{Name: name, Ingresses: count})
+	}
+	sort.Slice(ans.Items, func(i, j int) bool {
+		return ans.Items[i].Name < ans.Items[j].Name
+	})
+	writeJSON(writer, http.StatusOK, ans)
+}
+
+func toAPIIngress(ingress Ingress) api.Ingress {
+	ans := api.Ingress{
+		UID:         ingress.UID,
+		ID:          ingress.ID,
+		Name:        ingress.Name,
+		Namespace:   ingress.Namespace,
+		Title:       ingress.Title,
+		Label:       ingress.Label(),
+		Description: ingress.Description,
+		LogoURL:     ingress.Logo(),
+		Class:       ingress.Class,
+		Tags:        ingress.Tags,
+		Alias:       ingress.Alias,
+		Static:      ingress.Static,
+		TLS:         ingress.TLS,
+		TLSStatus:   ingress.TLSStatus(),
+		DeadRefs:    ingress.HasDeadRefs(),
+		Refs:        make([]api.Ref, 0, len(ingress.Refs)),
+	}
+	for _, ref := range ingress.Refs {
+		ans.Refs = append(ans.Refs, api.Ref{
+			URL:    ref.URL,
+			Pods:   ref.Pods,
+			Static: ref.Static,
+			Dead:   !ref.Static && ref.Pods == 0,
+		})
+	}
+	if !ingress.Cert.Expiration.IsZero() {
+		ans.Cert = &api.Cert{
+			Expiration: ingress.Cert.Expiration,
+			ExpiresIn:  int64(time.Until(ingress.Cert.Expiration) / time.Second),
+			Domains:    append([]string{}, ingress.Cert.Domains...),
+			Issuer:     ingress.Cert.Issuer,
+		}
+	}
+
+	return ans
+}
+
+func writeJSON(writer http.ResponseWriter, code int, value interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(code)
+	if err := json.NewEncoder(writer).Encode(value); err != nil {
+		log.Println("failed encode response:", err)
+	}
+}
@@ -0,0 +1,158 @@
+package internal_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/reddec/ingress-dashboard/internal"
+	"github.com/stretchr/testify/require"
+)
+
+func testService() *internal.Service {
+	svc := internal.New()
+	svc.Prepend([]internal.Ingress{{
+		ID:        "external.docs",
+		UID:       "static-docs",
+		Name:      "docs",
+		Namespace: "external",
+		Tags:      []string{"docs"},
+		Static:    true,
+		Refs:      []internal.Ref{{URL: "http://docs.example.com", Static: true}},
+	}})
+	svc.Set([]internal.Ingress{{
+		ID:          "default.grafana",
+		UID:         "uid-grafana",
+		Name:        "grafana",
+		Namespace:   "default",
+		Title:       "Grafana",
+		Description: "Monitoring dashboards",
+		Class:       "nginx",
+		Tags:        []string{"monitoring"},
+		TLS:         true,
+		Cert:        internal.CertInfo{Expiration: time.Now().Add(-time.Hour), Issuer: "CA"},
+		Refs:        []internal.Ref{{URL: "https://grafana.example.com/", Pods: 1}},
+	}, {
+		ID:        "monitoring.prometheus",
+		UID:       "uid-prometheus",
+		Name:      "prometheus",
+		Namespace: "monitoring",
+		Class:     "traefik",
+		Tags:      []string{"monitoring"},
+		Refs:      []internal.Ref{{URL: "http://prometheus.example.com/"}},
+	}, {
+		ID:        "default.secret",
+		UID:       "uid-secret",
+		Name:      "secret",
+		Namespace: "default",
+		Hide:      true,
+	}})
+
+	return svc
+}
+
+func getJSON(t *testing.T, handler http.Handler, path string, expectedCode int, out interface{}) {
+	t.Helper()
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, path, nil))
+	require.Equal(t, expectedCode, res.Code, res.Body.String())
+	require.Equal(t, "application/json", res.Header().Get("Content-Type"))
+	require.NoError(t, json.NewDecoder(res.Body).Decode(out))
+}
+
+type ingressList struct {
+	Items []struct {
+		UID       string `json:"uid"`
+		Label     string `json:"label"`
+		TLSStatus string `json:"tls_status"`
+		DeadRefs  bool   `json:"dead_refs"`
+		Cert      *struct {
+			Issuer    string `json:"issuer"`
+			ExpiresIn int64  `json:"expires_in"`
+		} `json:"cert"`
+	} `json:"items"`
+}
+
+func TestAPI_listIngresses(t *testing.T) {
+	svc := testService()
+
+	var all ingressList
+	getJSON(t, svc, "/api/v1/ingresses", http.StatusOK, &all)
+	require.Len(t, all.Items, 3, "hidden ingress should not be listed")
+	require.Equal(t, "static-docs", all.Items[0].UID)
+	require.Equal(t, "Grafana", all.Items[1].Label)
+	require.Equal(t, "expired", all.Items[1].TLSStatus)
+	require.NotNil(t, all.Items[1].Cert)
+	require.Equal(t, "CA", all.Items[1].Cert.Issuer)
+	require.Negative(t, all.Items[1].Cert.ExpiresIn)
+	require.True(t, all.Items[2].DeadRefs)
+	require.Nil(t, all.Items[2].Cert)
+
+	cases := map[string][]string{
+		"/api/v1/ingresses?namespace=default":                       {"uid-grafana"},
+		"/api/v1/ingresses?namespace=default&namespace=external":    {"static-docs", "uid-grafana"},
+		"/api/v1/ingresses?class=traefik":                           {"uid-prometheus"},
+		"/api/v1/ingresses?tag=monitoring":                          {"uid-grafana", "uid-prometheus"},
+		"/api/v1/ingresses?tls=expired":                             {"uid-grafana"},
+		"/api/v1/ingresses?tls=disabled":                            {"static-docs", "uid-prometheus"},
+		"/api/v1/ingresses?dead=true":                               {"uid-prometheus"},
+		"/api/v1/ingresses?q=DASHBOARD":                             {"uid-grafana"},
+		"/api/v1/ingresses?q=example.com&tag=monitoring&dead=false": {"uid-grafana"},
+	}
+	for path, expected := range cases {
+		var list ingressList
+		getJSON(t, svc, path, http.StatusOK, &list)
+		var uids = make([]string, 0, len(list.Items))
+		for _, item := range list.Items {
+			uids = append(uids, item.UID)
+		}
+		require.Equal(t, expected, uids, path)
+	}
+
+	var apiErr struct {
+		Error string `json:"error"`
+	}
+	getJSON(t, svc, "/api/v1/ingresses?tls=broken", http.StatusBadRequest, &apiErr)
+	require.NotEmpty(t, apiErr.Error)
+}
+
+func TestAPI_getIngress(t *testing.T) {
+	svc := testService()
+
+	var ingress struct {
+		UID  string `json:"uid"`
+		Refs []struct {
+			URL  string `json:"url"`
+			Pods int    `json:"pods"`
+		} `json:"refs"`
+	}
+	getJSON(t, svc, "/api/v1/ingresses/uid-grafana", http.StatusOK, &ingress)
+	require.Equal(t, "uid-grafana", ingress.UID)
+	require.Len(t, ingress.Refs, 1)
+	require.Equal(t, 1, ingress.Refs[0].Pods)
+
+	var apiErr struct {
+		Error string `json:"error"`
+	}
+	getJSON(t, svc, "/api/v1/ingresses/uid-secret", http.StatusNotFound, &apiErr)
+	getJSON(t, svc, "/api/v1/ingresses/unknown", http.StatusNotFound, &apiErr)
+}
+
+func TestAPI_listNamespaces(t *testing.T) {
+	svc := testService()
+
+	var namespaces struct {
+		Items []struct {
+			Name      string `json:"name"`
+			Ingresses int    `json:"ingresses"`
+		} `json:"items"`
+	}
+	getJSON(t, svc, "/api/v1/namespaces", http.StatusOK, &namespaces)
+	require.Len(t, namespaces.Items, 3)
+	require.Equal(t, "default", namespaces.Items[0].Name)
+	require.Equal(t, 1, namespaces.Items[0].Ingresses)
+	require.Equal(t, "external", namespaces.Items[1].Name)
+	require.Equal(t, "monitoring", namespaces.Items[2].Name)
+}
@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// NewToken passes requests with valid access token to next handler and all other requests to fallback handler
+// (usually protected by another auth scheme). Token is accepted from query parameter token or from
+// header Authorization: Bearer <token>. Header is preferred: query is written to access logs of proxies.
+// Empty token disables access by token.
+func NewToken(token string, next, fallback http.Handler) http.Handler {
+	return &tokenAuth{
+		token:    token,
+		next:     next,
+		fallback: fallback,
+	}
+}
+
+type tokenAuth struct {
+	token    string
+	next     http.Handler
+	fallback http.Handler
+}
+
+func (ta *tokenAuth) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if ta.token == "" || !ta.valid(request) {
+		ta.fallback.ServeHTTP(writer, request)
+
+		return
+	}
+	ta.next.ServeHTTP(writer, request)
+}
+
+func (ta *tokenAuth) valid(request *http.Request) bool {
+	token := request.URL.Query().Get("token")
+	if header := request.Header.Get("Authorization"); strings.HasPrefix(header, "Bearer ") {
+		token = strings.TrimPrefix(header, "Bearer ")
+	}
+
+	return subtle.ConstantTimeCompare([]byte(token), []byte(ta.token)) == 1
+}
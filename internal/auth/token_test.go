@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewToken(t *testing.T) {
+	next := http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+	})
+	fallback := http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusUnauthorized)
+	})
+	get := func(handler http.Handler, target, authorization string) int {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+
+		return res.Code
+	}
+
+	handler := NewToken("secret", next, fallback)
+
+	t.Run("query", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, get(handler, "/wall?token=secret", ""))
+	})
+
+	t.Run("header", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, get(handler, "/wall", "Bearer secret"))
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		require.Equal(t, http.StatusUnauthorized, get(handler, "/wall?token=wrong", ""))
+		require.Equal(t, http.StatusUnauthorized, get(handler, "/wall", "Bearer wrong"))
+		require.Equal(t, http.StatusUnauthorized, get(handler, "/wall", "Basic secret"))
+		require.Equal(t, http.StatusUnauthorized, get(handler, "/wall", ""))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		disabled := NewToken("", next, fallback)
+		require.Equal(t, http.StatusUnauthorized, get(disabled, "/wall?token=", ""))
+		require.Equal(t, http.StatusUnauthorized, get(disabled, "/wall", "Bearer "))
+		require.Equal(t, http.StatusUnauthorized, get(disabled, "/wall", ""))
+	})
+}
@@ -0,0 +1,240 @@
+package internal
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// Types of status badges.
+const (
+	BadgeHealth = "health" // the worst problem of entry
+	BadgeTLS    = "tls"    // certificate expiration
+	BadgeReady  = "ready"  // links served by at least one host
+)
+
+const (
+	badgeTypeParam  = "type"
+	badgeLabelParam = "label" // custom text of left part
+	badgePrefix     = "/badge/"
+	badgeSuffix     = ".svg"
+	badgeMaxAge     = time.Minute
+	badgeETagSize   = 8 // bytes of hash used for ETag
+)
+
+// Colors of badges, the same as in shields.io.
+const (
+	colorGreen  = "#4c1"
+	colorOrange = "#fe7d37"
+	colorRed    = "#e05d44"
+	colorGrey   = "#9f9f9f"
+	colorLabel  = "#555"
+)
+
+// Badge is shields-style status image: label on the left and colored message on the right.
+type Badge struct {
+	Label   string
+	Message string
+	Color   string
+}
+
+//nolint:gochecknoglobals
+var badgeMessages = map[string]string{ // short texts of problems
+	ProblemTLSExpired:    "certificate expired",
+	ProblemTLSSoonExpire: "certificate expires soon",
+	ProblemDeadRefs:      "down",
+	ProblemNoClass:       "no ingress class",
+	ProblemCertError:     "certificate error",
+}
+
+// badgeOf entry by type. Returns false for unknown type.
+func badgeOf(ingress Ingress, kind string) (Badge, bool) {
+	switch kind {
+	case BadgeHealth, "":
+		return healthBadge(ingress), true
+	case BadgeTLS:
+		return tlsBadge(ingress, time.Now()), true
+	case BadgeReady:
+		return readyBadge(ingress), true
+	default:
+		return Badge{}, false
+	}
+}
+
+// healthBadge shows the worst problem. Info problems (like missing logo) are ignored.
+func healthBadge(ingress Ingress) Badge {
+	badge := Badge{Label: BadgeHealth, Message: "healthy", Color: colorGreen}
+	if problems := problemsOf(ingress); len(problems) > 0 && problems[0].Severity >= SeverityWarning {
+		badge.Message = badgeMessages[problems[0].Kind]
+		badge.Color = colorOrange
+		if problems[0].Severity == SeverityCritical {
+			badge.Color = colorRed
+		}
+	}
+
+	return badge
+}
+
+func tlsBadge(ingress Ingress, now time.Time) Badge {
+	badge := Badge{Label: BadgeTLS}
+	days := int(ingress.Cert.Expiration.Sub(now) / (24 * time.Hour)) //nolint:gomnd
+	switch ingress.TLSStatus() {
+	case TLSDisabled:
+		badge.Message, badge.Color = "disabled", colorGrey
+	case TLSUnknown:
+		badge.Message, badge.Color = "unknown", colorGrey
+	case TLSExpired:
+		badge.Message, badge.Color = "expired", colorRed
+	case TLSSoonExpire:
+		badge.Message, badge.Color = expiresIn(days), colorOrange
+	default:
+		badge.Message, badge.Color = expiresIn(days), colorGreen
+	}
+
+	return badge
+}
+
+func expiresIn(days int) string {
+	switch days {
+	case 0:
+		return "expires today"
+	case 1:
+		return "expires in 1 day"
+	default:
+		return fmt.Sprintf("expires in %d days", days)
+	}
+}
+
+// readyBadge shows number of links served by at least one host. Hosts of static entries are not known.
+func readyBadge(ingress Ingress) Badge {
+	badge := Badge{Label: BadgeReady}
+	if ingress.Static {
+		badge.Message, badge.Color = "static", colorGrey
+
+		return badge
+	}
+	var ready int
+	for _, ref := range ingress.Refs {
+		if ref.Static || ref.Pods > 0 {
+			ready++
+		}
+	}
+	badge.Message = fmt.Sprintf("%d/%d up", ready, len(ingress.Refs))
+	switch {
+	case ready == len(ingress.Refs):
+		badge.Color = colorGreen
+	case ready == 0:
+		badge.Color = colorRed
+	default:
+		badge.Color = colorOrange
+	}
+
+	return badge
+}
+
+// SVG image of badge. Width of text is estimated: there are no fonts on server side.
+func (b Badge) SVG() []byte {
+	const (
+		charWidth = 7  // average width of Verdana 11px
+		padding   = 10 // sum of left and right paddings
+		height    = 20
+	)
+	labelWidth := utf8.RuneCountInString(b.Label)*charWidth + padding
+	messageWidth := utf8.RuneCountInString(b.Message)*charWidth + padding
+	width := labelWidth + messageWidth
+	label := html.EscapeString(b.Label)
+	message := html.EscapeString(b.Message)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" role="img" aria-label="%s: %s">`, width, height, label, message)
+	fmt.Fprintf(&buf, `<title>%s: %s</title>`, label, message)
+	buf.WriteString(`<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>`)
+	fmt.Fprintf(&buf, `<clipPath id="r"><rect width="%d" height="%d" rx="3" fill="#fff"/></clipPath>`, width, height)
+	fmt.Fprintf(&buf, `<g clip-path="url(#r)"><rect width="%d" height="%d" fill="%s"/><rect x="%d" width="%d" height="%d" fill="%s"/><rect width="%d" height="%d" fill="url(#s)"/></g>`,
+		labelWidth, height, colorLabel, labelWidth, messageWidth, height, html.EscapeString(b.Color), width, height)
+	buf.WriteString(`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">`)
+	for _, text := range []struct {
+		x     int
+		value string
+	}{{labelWidth / 2, label}, {labelWidth + messageWidth/2, message}} { //nolint:gomnd
+		fmt.Fprintf(&buf, `<text x="%d" y="15" fill="#010101" fill-opacity=".3">%s</text><text x="%d" y="14">%s</text>`, text.x, text.value, text.x, text.value)
+	}
+	buf.WriteString(`</g></svg>`)
+
+	return buf.Bytes()
+}
+
+// badgeEntry finds visible entry by UID (/badge/<uid>.svg) or by namespace and name (/badge/<namespace>/<name>.svg).
+func (svc *Service) badgeEntry(key, name string) (Ingress, bool) {
+	for _, ing := range visibleIngresses(svc.getList()) {
+		if name == "" && ing.UID == strings.TrimSuffix(key, badgeSuffix) ||
+			name != "" && ing.Namespace == key && ing.Name == strings.TrimSuffix(name, badgeSuffix) {
+			return ing, true
+		}
+	}
+
+	return Ingress{}, false
+}
+
+// PublicBadges serves badges of entries marked as public without authorization (for example, for README files).
+// All other requests are passed to fallback handler, which is usually protected.
+func (svc *Service) PublicBadges(fallback http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(request.URL.Path, badgePrefix), "/")
+		var key, name = parts[0], ""
+		if len(parts) > 1 {
+			name = parts[1]
+		}
+		if ing, ok := svc.badgeEntry(key, name); ok && ing.PublicBadge && len(parts) <= 2 { //nolint:gomnd
+			svc.ServeHTTP(writer, request)
+
+			return
+		}
+		fallback.ServeHTTP(writer, request)
+	})
+}
+
+func (svc *Service) getBadge(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	ingress, ok := svc.badgeEntry(params.ByName("key"), params.ByName("name"))
+	if !ok {
+		http.NotFound(writer, request)
+
+		return
+	}
+	query := request.URL.Query()
+	badge, ok := badgeOf(ingress, query.Get(badgeTypeParam))
+	if !ok {
+		http.Error(writer, "unknown badge type", http.StatusBadRequest)
+
+		return
+	}
+	if label := strings.TrimSpace(query.Get(badgeLabelParam)); label != "" {
+		badge.Label = label
+	}
+	image := badge.SVG()
+	hash := sha256.Sum256(image)
+	etag := `"` + hex.EncodeToString(hash[:badgeETagSize]) + `"`
+
+	// badges of private entries should not be kept by shared caches (proxies, CDN)
+	scope := "private"
+	if ingress.PublicBadge {
+		scope = "public"
+	}
+	writer.Header().Set("Cache-Control", fmt.Sprintf("%s, max-age=%d", scope, int(badgeMaxAge/time.Second)))
+	writer.Header().Set("ETag", etag)
+	if request.Header.Get("If-None-Match") == etag {
+		writer.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+	writer.Header().Set("Content-Type", "image/svg+xml; charset=utf-8")
+	_, _ = writer.Write(image)
+}
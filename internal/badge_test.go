@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBadges(t *testing.T) {
+	now := time.Now()
+	static := Ingress{Name: "docs", Static: true}
+	plain := Ingress{Name: "grafana-dev", Class: "nginx", Refs: []Ref{{URL: "http://grafana.dev.example.com", Pods: 1}}}
+	secured := Ingress{Name: "grafana", Class: "nginx", TLS: true, Refs: []Ref{{URL: "https://grafana.example.com", Pods: 1}}}
+	down := Ingress{Name: "prometheus", Class: "traefik", Refs: []Ref{{URL: "http://prometheus.example.com"}}}
+
+	require.Equal(t, Badge{Label: BadgeHealth, Message: "healthy", Color: colorGreen}, healthBadge(secured))
+	require.Equal(t, Badge{Label: BadgeHealth, Message: "down", Color: colorRed}, healthBadge(down))
+
+	require.Equal(t, Badge{Label: BadgeReady, Message: "static", Color: colorGrey}, readyBadge(static))
+	require.Equal(t, Badge{Label: BadgeReady, Message: "1/1 up", Color: colorGreen}, readyBadge(plain))
+	require.Equal(t, Badge{Label: BadgeReady, Message: "0/1 up", Color: colorRed}, readyBadge(down))
+
+	require.Equal(t, Badge{Label: BadgeTLS, Message: "disabled", Color: colorGrey}, tlsBadge(plain, now))
+	secured.Cert.Expiration = now.Add(5*24*time.Hour + time.Hour)
+	require.Equal(t, Badge{Label: BadgeTLS, Message: "expires in 5 days", Color: colorOrange}, tlsBadge(secured, now))
+	secured.Cert.Expiration = now.Add(-time.Hour)
+	require.Equal(t, Badge{Label: BadgeTLS, Message: "expired", Color: colorRed}, tlsBadge(secured, now))
+}
+
+func TestBadge_SVG(t *testing.T) {
+	image := string(Badge{Label: "<tls>", Message: "ok & fine", Color: colorGreen}.SVG())
+	require.Contains(t, image, `<title>&lt;tls&gt;: ok &amp; fine</title>`)
+	require.Contains(t, image, `fill="#4c1"`)
+	require.NotContains(t, image, "<tls>")
+}
+
+func TestService_badge(t *testing.T) {
+	svc := New()
+	svc.Set([]Ingress{
+		{UID: "grafana", Name: "grafana", Namespace: "monitoring", TLS: true, Refs: []Ref{{URL: "https://grafana.example.com", Pods: 1}}},
+		{UID: "prometheus", Name: "prometheus", Namespace: "monitoring", PublicBadge: true, Refs: []Ref{{URL: "http://prometheus.example.com"}}},
+	})
+
+	get := func(handler http.Handler, target string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+
+		return res
+	}
+
+	res := get(svc, "/badge/grafana.svg?type=ready", nil)
+	require.Equal(t, http.StatusOK, res.Code)
+	require.Equal(t, "image/svg+xml; charset=utf-8", res.Header().Get("Content-Type"))
+	require.Equal(t, "private, max-age=60", res.Header().Get("Cache-Control"))
+	require.Contains(t, res.Body.String(), "1/1 up")
+
+	t.Run("not modified", func(t *testing.T) {
+		etag := res.Header().Get("ETag")
+		require.NotEmpty(t, etag)
+		res := get(svc, "/badge/grafana.svg?type=ready", http.Header{"If-None-Match": {etag}})
+		require.Equal(t, http.StatusNotModified, res.Code)
+		require.Empty(t, res.Body.String())
+	})
+
+	t.Run("by namespace and name", func(t *testing.T) {
+		res := get(svc, "/badge/monitoring/prometheus.svg?label=api", nil)
+		require.Equal(t, http.StatusOK, res.Code)
+		require.Equal(t, "public, max-age=60", res.Header().Get("Cache-Control"))
+		require.Contains(t, res.Body.String(), "<title>api: down</title>")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		require.Equal(t, http.StatusNotFound, get(svc, "/badge/unknown.svg", nil).Code)
+		require.Equal(t, http.StatusNotFound, get(svc, "/badge/dev/prometheus.svg", nil).Code)
+		require.Equal(t, http.StatusBadRequest, get(svc, "/badge/grafana.svg?type=size", nil).Code)
+	})
+
+	t.Run("public without authorization", func(t *testing.T) {
+		protected := http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+			writer.WriteHeader(http.StatusUnauthorized)
+		})
+		handler := svc.PublicBadges(protected)
+		require.Equal(t, http.StatusOK, get(handler, "/badge/prometheus.svg", nil).Code)
+		require.Equal(t, http.StatusOK, get(handler, "/badge/monitoring/prometheus.svg", nil).Code)
+		require.Equal(t, http.StatusUnauthorized, get(handler, "/badge/grafana.svg", nil).Code)
+		require.Equal(t, http.StatusUnauthorized, get(handler, "/badge/unknown.svg", nil).Code)
+	})
+}
@@ -0,0 +1,118 @@
+package internal
+
+import (
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	bookmarksByNamespace = "namespace"
+	bookmarksByTag       = "tag"
+)
+
+type bookmarksFile struct {
+	Title     string
+	Folders   []bookmarksFolder
+	Bookmarks []bookmark // entries without folder
+}
+
+type bookmarksFolder struct {
+	Name      string
+	Bookmarks []bookmark
+}
+
+type bookmark struct {
+	Title string
+	URL   string
+	Icon  string // data URI
+}
+
+// getBookmarks returns Netscape bookmark file with visible entries grouped in folders by namespace (default)
+// or tag (group=tag). Entries could be filtered by the same parameters as index page.
+func (svc *Service) getBookmarks(writer http.ResponseWriter, request *http.Request, _ httprouter.Params) {
+	group := request.URL.Query().Get("group")
+	if group == "" {
+		group = bookmarksByNamespace
+	}
+	if group != bookmarksByNamespace && group != bookmarksByTag {
+		http.Error(writer, "unknown group "+group+", supported: namespace, tag", http.StatusBadRequest)
+
+		return
+	}
+
+	list := ParseFilter(request.URL.Query()).Search(visibleIngresses(svc.getList()))
+
+	var logos = make([]string, 0, len(list))
+	for _, ing := range list {
+		if logo := ing.Logo(); logo != "" {
+			logos = append(logos, logo)
+		}
+	}
+	icons := svc.icons.get(request.Context(), logos)
+
+	file := bookmarksFile{Title: svc.branding.Name()}
+	var folders = make(map[string]*bookmarksFolder)
+	add := func(folder string, items []bookmark) {
+		if len(items) == 0 {
+			return
+		}
+		if folder == "" {
+			file.Bookmarks = append(file.Bookmarks, items...)
+
+			return
+		}
+		f, ok := folders[folder]
+		if !ok {
+			f = &bookmarksFolder{Name: folder}
+			folders[folder] = f
+		}
+		f.Bookmarks = append(f.Bookmarks, items...)
+	}
+	for _, ing := range list {
+		items := toBookmarks(ing, icons[ing.Logo()])
+		if group == bookmarksByNamespace {
+			add(ing.Namespace, items)
+
+			continue
+		}
+		if len(ing.Tags) == 0 {
+			add("", items)
+		}
+		for _, tag := range ing.Tags {
+			add(tag, items)
+		}
+	}
+	for _, f := range folders {
+		file.Folders = append(file.Folders, *f)
+	}
+	sort.Slice(file.Folders, func(i, j int) bool {
+		return file.Folders[i].Name < file.Folders[j].Name
+	})
+
+	writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+	writer.Header().Set("Content-Disposition", `attachment; filename="bookmarks.html"`)
+	if err := svc.bookmarks.Execute(writer, file); err != nil {
+		log.Println("failed render bookmarks:", err)
+	}
+}
+
+// toBookmarks creates bookmark for each HTTP link of entry. Additional links are marked by host and path.
+func toBookmarks(ingress Ingress, icon string) []bookmark {
+	var ans []bookmark
+	for _, ref := range ingress.Refs {
+		if !strings.HasPrefix(ref.URL, "http://") && !strings.HasPrefix(ref.URL, "https://") {
+			continue
+		}
+		title := ingress.Label()
+		if len(ans) > 0 {
+			title += " (" + strings.TrimSuffix(strings.SplitN(ref.URL, "://", 2)[1], "/") + ")"
+		}
+		ans = append(ans, bookmark{Title: title, URL: ref.URL, Icon: icon})
+	}
+
+	return ans
+}
@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:gochecknoglobals
+var testPNG, _ = base64.StdEncoding.DecodeString("iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNk+M9QDwADhgGAWjR9awAAAABJRU5ErkJggg==")
+
+func TestService_bookmarks(t *testing.T) {
+	logos := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/logo.png" {
+			http.NotFound(writer, request)
+
+			return
+		}
+		_, _ = writer.Write(testPNG)
+	}))
+	defer logos.Close()
+
+	svc := New()
+	svc.Set([]Ingress{
+		{UID: "docs", Name: "docs", Namespace: "external", Static: true},
+		{UID: "grafana-dev", Name: "grafana-dev", Namespace: "dev", LogoURL: logos.URL + "/logo.png", Refs: []Ref{{URL: "http://grafana.dev.example.com"}}},
+		{UID: "grafana", Name: "grafana", Namespace: "monitoring", LogoURL: logos.URL + "/missing.png", Refs: []Ref{
+			{URL: "https://grafana.example.com"},
+			{URL: "https://grafana.example.com/admin/"},
+		}},
+		{UID: "prometheus", Name: "prometheus", Namespace: "monitoring", Tags: []string{"grafana-datasource"}, Refs: []Ref{{URL: "http://prometheus.example.com"}}},
+	})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		res := httptest.NewRecorder()
+		svc.ServeHTTP(res, httptest.NewRequest(http.MethodGet, path, nil))
+
+		return res
+	}
+
+	t.Run("by namespace", func(t *testing.T) {
+		res := get("/bookmarks.html")
+		require.Equal(t, http.StatusOK, res.Code)
+		require.Equal(t, `attachment; filename="bookmarks.html"`, res.Header().Get("Content-Disposition"))
+		body := res.Body.String()
+		require.True(t, strings.HasPrefix(body, "<!DOCTYPE NETSCAPE-Bookmark-file-1>"))
+		require.Contains(t, body, `<DT><H3>dev</H3>`)
+		require.Contains(t, body, `<A HREF="http://grafana.dev.example.com" ICON="data:image/png;base64,`)
+		require.Contains(t, body, `<A HREF="https://grafana.example.com">grafana</A>`)
+		require.Contains(t, body, `<A HREF="https://grafana.example.com/admin/">grafana (grafana.example.com/admin)</A>`)
+		require.NotContains(t, body, "external") // static entry without links
+		require.Less(t, strings.Index(body, "<H3>dev</H3>"), strings.Index(body, "<H3>monitoring</H3>"))
+	})
+
+	t.Run("by tag", func(t *testing.T) {
+		body := get("/bookmarks.html?group=tag").Body.String()
+		require.Contains(t, body, `<DT><H3>grafana-datasource</H3>`)
+		require.NotContains(t, body, `<DT><H3>monitoring</H3>`)
+		require.Contains(t, body, `<A HREF="http://grafana.dev.example.com"`)
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		body := get("/bookmarks.html?namespace=dev").Body.String()
+		require.Contains(t, body, "grafana-dev")
+		require.NotContains(t, body, "prometheus")
+	})
+
+	t.Run("unknown group", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, get("/bookmarks.html?group=class").Code)
+	})
+}
@@ -0,0 +1,124 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Themes of UI.
+const (
+	ThemeAuto  = "auto" // follow system preferences (prefers-color-scheme)
+	ThemeLight = "light"
+	ThemeDark  = "dark"
+)
+
+const defaultTitle = "Ingress Dashboard"
+
+var (
+	errInvalidColor = errors.New("accent color should be hex (#1188ee), rgb(...), hsl(...) or color name")
+	errInvalidTheme = errors.New("theme should be auto, light or dark")
+	errInvalidLink  = errors.New("link should be in format title=url")
+)
+
+//nolint:gochecknoglobals
+var colorPattern = regexp.MustCompile(`^(#[0-9a-fA-F]{3,8}|[a-zA-Z]+|(rgb|hsl)a?\([0-9.,%\s]+\))$`)
+
+// Branding of UI pages. Empty fields mean defaults.
+type Branding struct {
+	Title       string      `yaml:"title,omitempty" long:"title" env:"TITLE" description:"Title of dashboard"`
+	LogoURL     string      `yaml:"logo_url,omitempty" long:"logo-url" env:"LOGO_URL" description:"URL of logo shown near title"`
+	AccentColor string      `yaml:"accent_color,omitempty" long:"accent-color" env:"ACCENT_COLOR" description:"Accent color of links and buttons"`
+	Theme       string      `yaml:"theme,omitempty" long:"theme" env:"THEME" description:"Color theme: auto, light or dark" choice:"auto" choice:"light" choice:"dark"`
+	CustomCSS   string      `yaml:"custom_css,omitempty" long:"custom-css" env:"CUSTOM_CSS" description:"URL of additional stylesheet"`
+	Links       []BrandLink `yaml:"links,omitempty" long:"link" env:"LINKS" env-delim:"," description:"Footer link in format title=url"`
+}
+
+// BrandLink is link in the footer of pages.
+type BrandLink struct {
+	Title string `yaml:"title"`
+	URL   string `yaml:"url"`
+}
+
+// UnmarshalFlag parses link in format title=url.
+func (link *BrandLink) UnmarshalFlag(value string) error {
+	parts := strings.SplitN(value, "=", 2) //nolint:gomnd
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+		return fmt.Errorf("parse link %q: %w", value, errInvalidLink)
+	}
+	link.Title = strings.TrimSpace(parts[0])
+	link.URL = strings.TrimSpace(parts[1])
+
+	return nil
+}
+
+// Name of dashboard: title or default name.
+func (branding Branding) Name() string {
+	if branding.Title != "" {
+		return branding.Title
+	}
+
+	return defaultTitle
+}
+
+// DataTheme is value of data-theme attribute: forced theme or empty for automatic theme.
+func (branding Branding) DataTheme() string {
+	if branding.Theme == ThemeAuto {
+		return ""
+	}
+
+	return branding.Theme
+}
+
+// Validate branding. Colors are injected into styles, so they are checked strictly.
+func (branding Branding) Validate() error {
+	if branding.AccentColor != "" && !colorPattern.MatchString(branding.AccentColor) {
+		return fmt.Errorf("accent color %q: %w", branding.AccentColor, errInvalidColor)
+	}
+	switch branding.Theme {
+	case "", ThemeAuto, ThemeLight, ThemeDark:
+	default:
+		return fmt.Errorf("theme %q: %w", branding.Theme, errInvalidTheme)
+	}
+	for _, link := range branding.Links {
+		if link.Title == "" || link.URL == "" {
+			return fmt.Errorf("link %q: %w", link.Title+"="+link.URL, errInvalidLink)
+		}
+	}
+
+	return nil
+}
+
+// LoadBranding from YAML file and apply non-empty fields of override (from flags) on top of it.
+// Empty file name means override only.
+func LoadBranding(file string, override Branding) (Branding, error) {
+	var branding Branding
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return branding, fmt.Errorf("read branding: %w", err)
+		}
+		if err := yaml.Unmarshal(data, &branding); err != nil {
+			return branding, fmt.Errorf("parse branding %s: %w", file, err)
+		}
+	}
+	set := func(target *string, value string) {
+		if value != "" {
+			*target = value
+		}
+	}
+	set(&branding.Title, override.Title)
+	set(&branding.LogoURL, override.LogoURL)
+	set(&branding.AccentColor, override.AccentColor)
+	set(&branding.Theme, override.Theme)
+	set(&branding.CustomCSS, override.CustomCSS)
+	if len(override.Links) > 0 {
+		branding.Links = override.Links
+	}
+
+	return branding, branding.Validate()
+}
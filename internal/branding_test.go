@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadBranding(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "branding.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+title: ACME services
+accent_color: "#ff6600"
+theme: dark
+links:
+  - title: Support
+    url: https://support.example.com
+`), 0600))
+
+	branding, err := LoadBranding(file, Branding{Title: "ACME", Theme: ThemeAuto})
+	require.NoError(t, err)
+	require.Equal(t, Branding{
+		Title:       "ACME",
+		AccentColor: "#ff6600",
+		Theme:       ThemeAuto,
+		Links:       []BrandLink{{Title: "Support", URL: "https://support.example.com"}},
+	}, branding)
+
+	_, err = LoadBranding("", Branding{AccentColor: "red;} body {display: none"})
+	require.Error(t, err)
+
+	var link BrandLink
+	require.NoError(t, link.UnmarshalFlag("Wiki=https://wiki.example.com/?a=b"))
+	require.Equal(t, BrandLink{Title: "Wiki", URL: "https://wiki.example.com/?a=b"}, link)
+	require.Error(t, link.UnmarshalFlag("https://wiki.example.com"))
+}
+
+func TestService_branding(t *testing.T) {
+	svc, err := NewWithOptions(Options{Branding: Branding{
+		Title:       "ACME services",
+		LogoURL:     "https://example.com/logo.png",
+		AccentColor: "#ff6600",
+		Theme:       ThemeDark,
+		CustomCSS:   "https://example.com/custom.css",
+		Links:       []BrandLink{{Title: "Support", URL: "https://support.example.com"}},
+	}})
+	require.NoError(t, err)
+	svc.Set([]Ingress{{UID: "grafana", Name: "grafana", Namespace: "monitoring", Refs: []Ref{{URL: "https://grafana.example.com"}}}})
+
+	for _, path := range []string{"/", "/details/grafana", "/aliases", "/namespaces/monitoring", "/problems"} {
+		res := httptest.NewRecorder()
+		svc.ServeHTTP(res, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, res.Code)
+		body := res.Body.String()
+		require.Contains(t, body, `data-theme="dark">`, path)
+		require.Contains(t, body, `<title>ACME services`, path)
+		require.Contains(t, body, `<img src="https://example.com/logo.png" alt="logo">ACME services`, path)
+		// accent should win over colors of forced theme: the same specificity, but defined later
+		accent := strings.Index(body, ":root, :root[data-theme] {\n            --color: #ff6600;")
+		require.Greater(t, accent, strings.Index(body, `static/theme.css">`), path)
+		require.Contains(t, body, `<link rel="stylesheet" href="https://example.com/custom.css">`, path)
+		require.Contains(t, body, `<a href="https://support.example.com">Support</a>`, path)
+	}
+
+	_, err = NewWithOptions(Options{Branding: Branding{Theme: "blue"}})
+	require.Error(t, err)
+}
@@ -0,0 +1,338 @@
+package internal
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	changelogCapacity = 500 // number of changes kept in log
+	feedLimit         = 50  // number of changes in feed
+)
+
+type changeType string
+
+const (
+	changeAdded   changeType = "added"
+	changeRemoved changeType = "removed"
+	changeRenamed changeType = "renamed"
+)
+
+// catalogEntry is the state of the entry remembered between snapshots.
+type catalogEntry struct {
+	UID         string `json:"uid"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name"`
+	Title       string `json:"title"` // label of entry
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"` // first link
+}
+
+type changeRecord struct {
+	ID       uint64       `json:"id"`
+	Type     changeType   `json:"type"`
+	Time     time.Time    `json:"time"`
+	OldTitle string       `json:"old_title,omitempty"` // only for renamed entries
+	Entry    catalogEntry `json:"entry"`
+}
+
+// changelogState is persisted between restarts.
+type changelogState struct {
+	LastID  uint64                  `json:"last_id"`
+	Known   map[string]catalogEntry `json:"known"` // by namespace and name
+	Changes []changeRecord          `json:"changes"`
+}
+
+func newChangelog() *changelog {
+	return &changelog{state: changelogState{
+		// the same as for events: IDs should not repeat after restart without persistence
+		LastID: uint64(time.Now().UnixNano()/int64(time.Millisecond)) * eventIDScale,
+	}}
+}
+
+// changelog of added, removed and renamed entries, derived from successive snapshots. The first snapshot is used
+// as baseline and produces no changes. Entries are identified by namespace and name, so re-created objects are
+// not reported.
+type changelog struct {
+	lock  sync.RWMutex
+	file  string // empty means in-memory only
+	state changelogState
+}
+
+// load changelog from file and persist all future changes to it. Missing file is not an error.
+func (cl *changelog) load(file string) error {
+	cl.lock.Lock()
+	defer cl.lock.Unlock()
+	cl.file = file
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read changelog: %w", err)
+	}
+	var state changelogState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("parse changelog %s: %w", file, err)
+	}
+	cl.state = state
+
+	return nil
+}
+
+// observe snapshot of visible entries and record changes since previous snapshot.
+func (cl *changelog) observe(list []Ingress, now time.Time) error {
+	var current = make(map[string]catalogEntry, len(list))
+	var order []string
+	for _, ing := range list {
+		key := ing.Namespace + "/" + ing.Name
+		if _, ok := current[key]; ok {
+			continue
+		}
+		entry := catalogEntry{
+			UID:         ing.UID,
+			Namespace:   ing.Namespace,
+			Name:        ing.Name,
+			Title:       ing.Label(),
+			Description: ing.Description,
+		}
+		if len(ing.Refs) > 0 {
+			entry.URL = ing.Refs[0].URL
+		}
+		current[key] = entry
+		order = append(order, key)
+	}
+
+	cl.lock.Lock()
+	defer cl.lock.Unlock()
+	if cl.state.Known == nil {
+		cl.state.Known = current
+
+		return cl.save()
+	}
+
+	var changes []changeRecord
+	var modified bool
+	for _, key := range order {
+		entry := current[key]
+		previous, exists := cl.state.Known[key]
+		switch {
+		case !exists:
+			changes = append(changes, changeRecord{Type: changeAdded, Entry: entry})
+		case previous.Title != entry.Title:
+			changes = append(changes, changeRecord{Type: changeRenamed, Entry: entry, OldTitle: previous.Title})
+		case previous != entry:
+			modified = true
+		}
+	}
+	var removed []string
+	for key := range cl.state.Known {
+		if _, exists := current[key]; !exists {
+			removed = append(removed, key)
+		}
+	}
+	sort.Strings(removed)
+	for _, key := range removed {
+		changes = append(changes, changeRecord{Type: changeRemoved, Entry: cl.state.Known[key]})
+	}
+
+	if len(changes) == 0 && !modified {
+		return nil
+	}
+	cl.state.Known = current
+	for _, change := range changes {
+		cl.state.LastID++
+		change.ID = cl.state.LastID
+		change.Time = now
+		cl.state.Changes = append(cl.state.Changes, change)
+	}
+	if n := len(cl.state.Changes); n > changelogCapacity {
+		cl.state.Changes = append([]changeRecord{}, cl.state.Changes[n-changelogCapacity:]...)
+	}
+
+	return cl.save()
+}
+
+// latest changes (newest first) optionally filtered by namespaces.
+func (cl *changelog) latest(namespaces []string, limit int) []changeRecord {
+	cl.lock.RLock()
+	defer cl.lock.RUnlock()
+	var ans []changeRecord
+	for i := len(cl.state.Changes) - 1; i >= 0 && len(ans) < limit; i-- {
+		change := cl.state.Changes[i]
+		if len(namespaces) > 0 && !contains(namespaces, change.Entry.Namespace) {
+			continue
+		}
+		ans = append(ans, change)
+	}
+
+	return ans
+}
+
+// save state atomically. Should be called under lock.
+func (cl *changelog) save() error {
+	if cl.file == "" {
+		return nil
+	}
+	data, err := json.Marshal(cl.state)
+	if err != nil {
+		return fmt.Errorf("encode changelog: %w", err)
+	}
+	if err := writeFileAtomic(cl.file, data); err != nil {
+		return fmt.Errorf("save changelog: %w", err)
+	}
+
+	return nil
+}
+
+// writeFileAtomic replaces content of file by temporary file, so readers never see partially written file.
+func writeFileAtomic(file string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(file), "."+filepath.Base(file)+".*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+
+		return fmt.Errorf("write: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), file); err != nil {
+		return fmt.Errorf("replace: %w", err)
+	}
+
+	return nil
+}
+
+// LoadChangelog restores log of catalog changes from the file. All following changes will be saved to the file.
+// Without file changes are kept only in memory and the first snapshot after restart is used as baseline.
+func (svc *Service) LoadChangelog(file string) error {
+	return svc.changes.load(file)
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  atomPerson  `xml:"author"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID       string       `xml:"id"`
+	Title    string       `xml:"title"`
+	Updated  string       `xml:"updated"`
+	Links    []atomLink   `xml:"link"`
+	Category atomCategory `xml:"category"`
+	Summary  string       `xml:"summary,omitempty"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// getFeed renders latest changes of catalog as Atom feed. Feed could be limited by namespaces (namespace=...).
+func (svc *Service) getFeed(writer http.ResponseWriter, request *http.Request, _ httprouter.Params) {
+	base := serverURL(request)
+	namespaces := request.URL.Query()["namespace"]
+	self := base + "/feed.atom"
+	title := svc.branding.Name() + ": changes"
+	if len(namespaces) > 0 {
+		self += "?" + request.URL.Query().Encode()
+		title += " in " + strings.Join(namespaces, ", ")
+	}
+
+	feed := atomFeed{
+		ID:      self,
+		Title:   title,
+		Updated: time.Now().UTC().Format(time.RFC3339),
+		Author:  atomPerson{Name: svc.branding.Name()},
+		Links: []atomLink{
+			{Href: self, Rel: "self", Type: "application/atom+xml"},
+			{Href: base + "/", Rel: "alternate", Type: "text/html"},
+		},
+	}
+	changes := svc.changes.latest(namespaces, feedLimit)
+	if len(changes) > 0 {
+		feed.Updated = changes[0].Time.UTC().Format(time.RFC3339)
+	}
+	for _, change := range changes {
+		feed.Entries = append(feed.Entries, toAtomEntry(base, change))
+	}
+
+	writer.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	_, _ = writer.Write([]byte(xml.Header))
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(feed); err != nil {
+		log.Println("failed encode feed:", err)
+	}
+}
+
+func toAtomEntry(base string, change changeRecord) atomEntry {
+	entry := change.Entry
+	ans := atomEntry{
+		ID:       base + "/feed.atom#" + strconv.FormatUint(change.ID, 10),
+		Updated:  change.Time.UTC().Format(time.RFC3339),
+		Category: atomCategory{Term: string(change.Type)},
+	}
+	switch change.Type {
+	case changeAdded:
+		ans.Title = "New: " + entry.Title
+	case changeRemoved:
+		ans.Title = "Removed: " + entry.Title
+	case changeRenamed:
+		ans.Title = "Renamed: " + change.OldTitle + " → " + entry.Title
+	}
+	if entry.Namespace != "" {
+		ans.Title += " (" + entry.Namespace + ")"
+	}
+
+	if change.Type == changeRemoved {
+		ans.Links = append(ans.Links, atomLink{Href: base + "/", Rel: "alternate", Type: "text/html"})
+	} else {
+		ans.Links = append(ans.Links, atomLink{Href: base + "/details/" + entry.UID, Rel: "alternate", Type: "text/html"})
+	}
+	if entry.URL != "" {
+		ans.Links = append(ans.Links, atomLink{Href: entry.URL, Rel: "related"})
+	}
+
+	var summary []string
+	if entry.Description != "" {
+		summary = append(summary, entry.Description)
+	}
+	if entry.URL != "" {
+		summary = append(summary, entry.URL)
+	}
+	ans.Summary = strings.Join(summary, "\n")
+
+	return ans
+}
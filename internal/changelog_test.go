@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangelog_observe(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "changelog.json")
+	now := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	list := []Ingress{
+		{UID: "docs", Name: "docs", Namespace: "external"},
+		{UID: "grafana-dev", Name: "grafana-dev", Namespace: "dev"},
+		{UID: "grafana", Name: "grafana", Namespace: "monitoring"},
+	}
+
+	cl := newChangelog()
+	require.NoError(t, cl.load(file))
+	require.NoError(t, cl.observe(list, now))
+	require.Empty(t, cl.latest(nil, feedLimit), "baseline should not produce changes")
+
+	// the same entry re-created with new UID is not a change
+	list[0].UID = "docs-2"
+	require.NoError(t, cl.observe(list, now))
+	require.Empty(t, cl.latest(nil, feedLimit))
+
+	list[1].Title = "Grafana (dev)"
+	list = append(list[1:], Ingress{UID: "loki", Name: "loki", Namespace: "monitoring"})
+	require.NoError(t, cl.observe(list, now))
+
+	changes := cl.latest(nil, feedLimit)
+	require.Len(t, changes, 3)
+	require.Equal(t, changeRemoved, changes[0].Type)
+	require.Equal(t, "docs", changes[0].Entry.Name)
+	require.Equal(t, changeAdded, changes[1].Type)
+	require.Equal(t, "loki", changes[1].Entry.Name)
+	require.Equal(t, changeRenamed, changes[2].Type)
+	require.Equal(t, "grafana-dev", changes[2].OldTitle)
+	require.Equal(t, "Grafana (dev)", changes[2].Entry.Title)
+	require.Greater(t, changes[0].ID, changes[2].ID)
+
+	require.Len(t, cl.latest([]string{"monitoring"}, feedLimit), 1)
+
+	t.Run("restored after restart", func(t *testing.T) {
+		restored := newChangelog()
+		require.NoError(t, restored.load(file))
+		require.Equal(t, changes, restored.latest(nil, feedLimit))
+
+		// diff against persisted state, not a new baseline
+		require.NoError(t, restored.observe(list[1:], now))
+		require.Len(t, restored.latest(nil, feedLimit), 4)
+	})
+}
+
+func TestService_feed(t *testing.T) {
+	list := []Ingress{
+		{UID: "grafana-dev", Name: "grafana-dev", Namespace: "dev"},
+		{UID: "prometheus", Name: "prometheus", Namespace: "monitoring"},
+	}
+	svc := New()
+	svc.Set(list)
+	svc.Set(append(list, Ingress{
+		UID:         "loki",
+		Name:        "loki",
+		Namespace:   "monitoring",
+		Description: "Logs",
+		Refs:        []Ref{{URL: "https://loki.example.com"}},
+	}))
+	svc.Set(list[:1])
+
+	get := func(path string) string {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Host = "dashboard.example.com"
+		res := httptest.NewRecorder()
+		svc.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code)
+		require.Equal(t, "application/atom+xml; charset=utf-8", res.Header().Get("Content-Type"))
+
+		return res.Body.String()
+	}
+
+	t.Run("all", func(t *testing.T) {
+		body := get("/feed.atom")
+		require.Contains(t, body, `<feed xmlns="http://www.w3.org/2005/Atom">`)
+		require.Contains(t, body, `<title>New: loki (monitoring)</title>`)
+		require.Contains(t, body, `<link href="http://dashboard.example.com/details/loki" rel="alternate" type="text/html"></link>`)
+		require.Contains(t, body, `<summary>Logs&#xA;https://loki.example.com</summary>`)
+		require.Contains(t, body, `<title>Removed: prometheus (monitoring)</title>`)
+		require.Contains(t, body, `<title>Removed: loki (monitoring)</title>`)
+	})
+
+	t.Run("by namespace", func(t *testing.T) {
+		body := get("/feed.atom?namespace=dev")
+		require.Contains(t, body, `<title>Ingress Dashboard: changes in dev</title>`)
+		require.Contains(t, body, `<id>http://dashboard.example.com/feed.atom?namespace=dev</id>`)
+		require.NotContains(t, body, "<entry>")
+	})
+}
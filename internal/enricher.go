@@ -0,0 +1,248 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+)
+
+var errNoRefs = errors.New("no links to check")
+
+func newEnricher(receiver Receiver) *enricher {
+	return &enricher{
+		cache:      make(map[string]Ingress),
+		receiver:   receiver,
+		checkLogos: make(chan struct{}, 1),
+		checkCerts: make(chan struct{}, 1),
+	}
+}
+
+// enricher keeps list of ingresses and discovers additional information (logo, TLS certificates) for them.
+type enricher struct {
+	cache      map[string]Ingress
+	order      []string // optional order of UIDs, by default items are sorted by ID
+	lock       sync.RWMutex
+	receiver   Receiver
+	checkLogos chan struct{}
+	checkCerts chan struct{}
+}
+
+func (en *enricher) runLogoFetcher(ctx context.Context) {
+	for {
+		started := time.Now()
+		for _, ing := range en.items() {
+			if !ing.Hide && ing.LogoURL == "" && len(ing.Refs) > 0 {
+				logoURL, err := detectIconURL(ctx, ing.Refs[0].URL)
+				if err == nil {
+					ing.LogoURL = logoURL
+					en.updateLogo(ing)
+				} else {
+					en.updateLogoError(ing.UID, err)
+				}
+			}
+		}
+		observeScan(scanLogo, started)
+		en.receiver.Set(en.items())
+		select {
+		case <-ctx.Done():
+			return
+		case <-en.checkLogos:
+		}
+	}
+}
+
+func (en *enricher) notify() {
+	en.receiver.Set(en.items())
+	select {
+	case en.checkLogos <- struct{}{}:
+	default:
+	}
+
+	select {
+	case en.checkCerts <- struct{}{}:
+	default:
+	}
+}
+
+func (en *enricher) items() []Ingress {
+	en.lock.RLock()
+	defer en.lock.RUnlock()
+
+	if en.order != nil {
+		var cp = make([]Ingress, 0, len(en.cache))
+		for _, uid := range en.order {
+			if ing, ok := en.cache[uid]; ok {
+				cp = append(cp, ing)
+			}
+		}
+
+		return cp
+	}
+
+	return toList(en.cache)
+}
+
+// upsert ingress to cache. Discovered logo and TLS info are preserved.
+func (en *enricher) upsert(ingress Ingress) {
+	en.lock.Lock()
+	defer en.lock.Unlock()
+
+	if oldIngress, exists := en.cache[ingress.UID]; exists {
+		// preserve discovered logo
+		oldLogoURL := oldIngress.LogoURL
+		if oldLogoURL != "" && ingress.LogoURL == "" {
+			ingress.LogoURL = oldLogoURL
+			ingress.LogoFound = oldIngress.LogoFound
+		}
+
+		// preserve cert info as initial value
+		if !oldIngress.Cert.Expiration.IsZero() && ingress.Cert.Expiration.IsZero() {
+			ingress.Cert = oldIngress.Cert
+		}
+
+		// preserve errors of discovery till the next scan
+		ingress.CertError = oldIngress.CertError
+		if ingress.LogoURL == "" {
+			ingress.LogoError = oldIngress.LogoError
+		}
+	}
+
+	en.cache[ingress.UID] = ingress
+}
+
+func (en *enricher) remove(uid string) {
+	en.lock.Lock()
+	defer en.lock.Unlock()
+	delete(en.cache, uid)
+}
+
+func (en *enricher) updateLogo(ingress Ingress) {
+	en.lock.Lock()
+	defer en.lock.Unlock()
+	old, exists := en.cache[ingress.UID]
+	if !exists || old.LogoURL != "" {
+		return
+	}
+	old.LogoURL = ingress.LogoURL
+	old.LogoFound = true
+	old.LogoError = ""
+	en.cache[ingress.UID] = old
+}
+
+// updateLogoError of entry. Only the first failure after success is counted in metrics: discovery is retried
+// on every change of catalog.
+func (en *enricher) updateLogoError(uid string, err error) {
+	en.lock.Lock()
+	defer en.lock.Unlock()
+	old, exists := en.cache[uid]
+	if !exists {
+		return
+	}
+	if old.LogoError == "" {
+		scanErrors.WithLabelValues(scanLogo).Inc()
+	}
+	old.LogoError = err.Error()
+	en.cache[uid] = old
+}
+
+func (en *enricher) updateCertInfo(ingress Ingress) {
+	en.lock.Lock()
+	defer en.lock.Unlock()
+	old, exists := en.cache[ingress.UID]
+	if !exists {
+		return
+	}
+	old.Cert = ingress.Cert
+	old.CertError = ""
+	en.cache[ingress.UID] = old
+}
+
+// updateCertError of entry. Only the first failure after success is counted in metrics, like for logo.
+func (en *enricher) updateCertError(uid string, err error) {
+	en.lock.Lock()
+	defer en.lock.Unlock()
+	old, exists := en.cache[uid]
+	if !exists {
+		return
+	}
+	if old.CertError == "" {
+		scanErrors.WithLabelValues(scanTLS).Inc()
+	}
+	old.CertError = err.Error()
+	en.cache[uid] = old
+}
+
+func (en *enricher) runCertsInfoCheck(ctx context.Context) {
+	timer := time.NewTicker(tlsInterval)
+	defer timer.Stop()
+
+	for {
+		en.scanTLSCerts(ctx)
+		select {
+		case <-en.checkCerts:
+		case <-timer.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (en *enricher) scanTLSCerts(ctx context.Context) {
+	defer observeScan(scanTLS, time.Now())
+	for _, item := range en.items() {
+		if !item.TLS {
+			continue
+		}
+
+		info, err := fetchCertInfo(ctx, item)
+		if err != nil {
+			log.Println("no cert info for", item.ID, ":", err)
+			en.updateCertError(item.UID, err)
+
+			continue
+		}
+
+		item.Cert = *info
+		en.updateCertInfo(item)
+		en.receiver.Set(en.items())
+	}
+	en.receiver.Set(en.items())
+}
+
+// fetchCertInfo from the first reachable link. Error of the last attempt is returned if all links failed.
+func fetchCertInfo(ctx context.Context, item Ingress) (*CertInfo, error) {
+	var lastErr = errNoRefs
+	for _, u := range item.Refs {
+		if parsedURL, err := url.Parse(u.URL); err == nil {
+			host := parsedURL.Hostname()
+			crtInfo, err := Expiration(ctx, host)
+			if err != nil {
+				log.Println("failed get expiration time", host, ":", err)
+				lastErr = fmt.Errorf("%s: %w", host, err)
+
+				continue
+			}
+
+			return &crtInfo, nil // stop on first ref
+		}
+	}
+
+	return nil, lastErr
+}
+
+func toList(cache map[string]Ingress) []Ingress {
+	var cp = make([]Ingress, 0, len(cache))
+	for _, ing := range cache {
+		cp = append(cp, ing)
+	}
+	sort.Slice(cp, func(i, j int) bool {
+		return cp[i].ID < cp[j].ID
+	})
+
+	return cp
+}
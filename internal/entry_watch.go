@@ -0,0 +1,286 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	ConditionAccepted        = "Accepted" // condition type of DashboardEntry status
+	ReasonValid              = "Valid"
+	ReasonInvalid            = "ValidationFailed"
+	DashboardEntryAPIVersion = "ingress-dashboard.reddec.net/v1alpha1"
+	DashboardEntryKind       = "DashboardEntry"
+)
+
+// DashboardEntryResource is group-version-resource of DashboardEntry custom resource.
+//
+//nolint:gochecknoglobals
+var DashboardEntryResource = schema.GroupVersionResource{
+	Group:    "ingress-dashboard.reddec.net",
+	Version:  "v1alpha1",
+	Resource: "dashboardentries",
+}
+
+var (
+	errNoURLs         = errors.New("at least one URL should be defined")
+	errInvalidURL     = errors.New("URL should be absolute with http or https scheme")
+	errInvalidLogoURL = errors.New("logo should be absolute URL or path started from /")
+	errInvalidAlias   = errors.New("alias may contain only letters, digits, dots, dashes, underscores and tildes")
+)
+
+// DashboardEntry is custom resource which defines external link in dashboard.
+type DashboardEntry struct {
+	v1.TypeMeta   `json:",inline"`
+	v1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DashboardEntrySpec   `json:"spec"`
+	Status DashboardEntryStatus `json:"status,omitempty"`
+}
+
+type DashboardEntrySpec struct {
+	Title        string            `json:"title,omitempty"`        // custom title in dashboard, overwrites name
+	Description  string            `json:"description,omitempty"`  // optional, human-readable description
+	Descriptions map[string]string `json:"descriptions,omitempty"` // optional, descriptions by language
+	URLs         []string          `json:"urls"`                   // list of links, at least one required
+	Logo         string            `json:"logo,omitempty"`         // custom URL for icon
+	Tags         []string          `json:"tags,omitempty"`         // optional list of tags
+	Alias        string            `json:"alias,omitempty"`        // short name for /go/ links, name is used by default
+	Hide         bool              `json:"hide,omitempty"`         // hidden entries will not appear in UI
+	PublicBadge  bool              `json:"publicBadge,omitempty"`  // status badges are available without authorization
+}
+
+type DashboardEntryStatus struct {
+	Conditions []v1.Condition `json:"conditions,omitempty"`
+}
+
+// Validate entry specification.
+func (entry *DashboardEntry) Validate() error {
+	var problems []string
+	if len(entry.Spec.URLs) == 0 {
+		problems = append(problems, errNoURLs.Error())
+	}
+	for _, u := range entry.Spec.URLs {
+		if parsed, err := url.Parse(u); err != nil || !parsed.IsAbs() || parsed.Host == "" || !(parsed.Scheme == "http" || parsed.Scheme == "https") {
+			problems = append(problems, fmt.Sprintf("%s: %v", u, errInvalidURL))
+		}
+	}
+	if logo := entry.Spec.Logo; logo != "" && !strings.HasPrefix(logo, "/") {
+		if parsed, err := url.Parse(logo); err != nil || !parsed.IsAbs() {
+			problems = append(problems, errInvalidLogoURL.Error())
+		}
+	}
+	if alias := entry.Spec.Alias; alias != "" && !validAlias(alias) {
+		problems = append(problems, errInvalidAlias.Error())
+	}
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; ")) //nolint:goerr113
+	}
+
+	return nil
+}
+
+// Ingress representation of entry.
+func (entry *DashboardEntry) Ingress() Ingress {
+	ingress := Ingress{
+		ID:           entry.Namespace + "." + entry.Name,
+		UID:          string(entry.UID),
+		Title:        entry.Spec.Title,
+		Name:         entry.Name,
+		Namespace:    entry.Namespace,
+		Description:  entry.Spec.Description,
+		Descriptions: entry.Spec.Descriptions,
+		Hide:         entry.Spec.Hide,
+		LogoURL:      entry.Spec.Logo,
+		Tags:         entry.Spec.Tags,
+		Alias:        entry.Spec.Alias,
+		PublicBadge:  entry.Spec.PublicBadge,
+		Static:       true,
+	}
+	for _, u := range entry.Spec.URLs {
+		ingress.Refs = append(ingress.Refs, Ref{
+			URL:    u,
+			Static: true,
+		})
+		ingress.TLS = ingress.TLS || strings.HasPrefix(u, "https://")
+	}
+
+	return ingress
+}
+
+// WatchDashboardEntries watches DashboardEntry custom resources cluster-wide, pushes valid entries to receiver
+// and reports validation result in status conditions. Sync status and watch errors are reported to health (optional).
+// Blocks till context canceled.
+func WatchDashboardEntries(global context.Context, client dynamic.Interface, receiver Receiver, health *Health) {
+	ctx, cancel := context.WithCancel(global)
+	defer cancel()
+
+	watcher := &entryWatcher{
+		enricher: newEnricher(receiver),
+		global:   ctx,
+		client:   client,
+		health:   health,
+		handled:  newHandledKeys(),
+	}
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer cancel()
+		watcher.runWatcher(ctx)
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer cancel()
+		watcher.runLogoFetcher(ctx)
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer cancel()
+		watcher.runCertsInfoCheck(ctx)
+	}()
+	wg.Wait()
+}
+
+// ListDashboardEntries returns valid DashboardEntry resources from all namespaces. Logo and TLS info are not discovered.
+func ListDashboardEntries(ctx context.Context, client dynamic.Interface) ([]Ingress, error) {
+	list, err := client.Resource(DashboardEntryResource).List(ctx, v1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list dashboard entries: %w", err)
+	}
+	var ans []Ingress
+	for _, object := range list.Items {
+		var entry DashboardEntry
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, &entry); err != nil {
+			return nil, fmt.Errorf("decode dashboard entry %s/%s: %w", object.GetNamespace(), object.GetName(), err)
+		}
+		if entry.Validate() != nil {
+			continue
+		}
+		ans = append(ans, entry.Ingress())
+	}
+
+	return ans, nil
+}
+
+type entryWatcher struct {
+	*enricher
+	global  context.Context
+	client  dynamic.Interface
+	health  *Health
+	handled *handledKeys
+}
+
+func (ew *entryWatcher) runWatcher(ctx context.Context) {
+	informerFactory := dynamicinformer.NewDynamicSharedInformerFactory(ew.client, syncInterval)
+	informer := informerFactory.ForResource(DashboardEntryResource).Informer()
+
+	informer.AddEventHandler(ew)
+	trackSync(ctx, ew.health, SourceEntries, informer, ew.handled, ew.notify)
+	informer.Run(ctx.Done())
+}
+
+func (ew *entryWatcher) OnAdd(obj interface{}) {
+	ew.upsertEntry(ew.global, obj)
+	ew.handled.add(obj)
+}
+
+func (ew *entryWatcher) OnUpdate(_, newObj interface{}) {
+	ew.upsertEntry(ew.global, newObj)
+	ew.handled.add(newObj)
+}
+
+func (ew *entryWatcher) OnDelete(obj interface{}) {
+	defer ew.notify()
+	ew.handled.remove(obj)
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	object, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	ew.remove(string(object.GetUID()))
+}
+
+func (ew *entryWatcher) upsertEntry(ctx context.Context, obj interface{}) {
+	defer ew.notify()
+	object, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	var entry DashboardEntry
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, &entry); err != nil {
+		log.Println("failed decode dashboard entry", object.GetNamespace()+"/"+object.GetName(), ":", err)
+
+		return
+	}
+
+	validationErr := entry.Validate()
+	if validationErr != nil {
+		ew.remove(string(entry.UID))
+	} else {
+		ew.upsert(entry.Ingress())
+	}
+
+	if err := ew.updateStatus(ctx, &entry, validationErr); err != nil {
+		log.Println("failed update status of dashboard entry", entry.Namespace+"/"+entry.Name, ":", err)
+	}
+}
+
+// updateStatus sets Accepted condition based on validation result. Status is updated only if condition changed.
+func (ew *entryWatcher) updateStatus(ctx context.Context, entry *DashboardEntry, validationErr error) error {
+	condition := v1.Condition{
+		Type:               ConditionAccepted,
+		Status:             v1.ConditionTrue,
+		ObservedGeneration: entry.Generation,
+		Reason:             ReasonValid,
+		Message:            "entry is valid",
+	}
+	if validationErr != nil {
+		condition.Status = v1.ConditionFalse
+		condition.Reason = ReasonInvalid
+		condition.Message = validationErr.Error()
+	}
+
+	if old := meta.FindStatusCondition(entry.Status.Conditions, ConditionAccepted); old != nil &&
+		old.Status == condition.Status &&
+		old.Reason == condition.Reason &&
+		old.Message == condition.Message &&
+		old.ObservedGeneration == condition.ObservedGeneration {
+		return nil
+	}
+	meta.SetStatusCondition(&entry.Status.Conditions, condition)
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(entry)
+	if err != nil {
+		return fmt.Errorf("encode entry: %w", err)
+	}
+
+	_, err = ew.client.Resource(DashboardEntryResource).Namespace(entry.Namespace).UpdateStatus(ctx, &unstructured.Unstructured{Object: content}, v1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("update status: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+func TestEntryWatcher(t *testing.T) {
+	valid := newEntry(t, "valid", DashboardEntrySpec{
+		Title: "Valid",
+		URLs:  []string{"https://example.com"},
+		Tags:  []string{"external"},
+	})
+	invalid := newEntry(t, "invalid", DashboardEntrySpec{
+		URLs: []string{"example.com"},
+	})
+
+	client := fake.NewSimpleDynamicClient(runtime.NewScheme(), valid, invalid)
+
+	var received []Ingress
+	watcher := &entryWatcher{
+		enricher: newEnricher(ReceiverFunc(func(ingresses []Ingress) {
+			received = ingresses
+		})),
+		global: context.Background(),
+		client: client,
+	}
+
+	watcher.OnAdd(valid)
+	watcher.OnAdd(invalid)
+
+	require.Len(t, received, 1)
+	require.Equal(t, "Valid", received[0].Title)
+	require.Equal(t, "default.valid", received[0].ID)
+	require.Equal(t, []string{"external"}, received[0].Tags)
+	require.True(t, received[0].TLS)
+	require.True(t, received[0].Static)
+
+	requireCondition(t, client, "valid", v1.ConditionTrue)
+	requireCondition(t, client, "invalid", v1.ConditionFalse)
+
+	watcher.OnDelete(valid)
+	require.Empty(t, received)
+}
+
+func newEntry(t *testing.T, name string, spec DashboardEntrySpec) *unstructured.Unstructured {
+	t.Helper()
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&DashboardEntry{
+		TypeMeta: v1.TypeMeta{
+			APIVersion: DashboardEntryAPIVersion,
+			Kind:       DashboardEntryKind,
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			UID:       types.UID("uid-" + name),
+		},
+		Spec: spec,
+	})
+	require.NoError(t, err)
+
+	return &unstructured.Unstructured{Object: content}
+}
+
+func requireCondition(t *testing.T, client *fake.FakeDynamicClient, name string, status v1.ConditionStatus) {
+	t.Helper()
+	object, err := client.Resource(DashboardEntryResource).Namespace("default").Get(context.Background(), name, v1.GetOptions{})
+	require.NoError(t, err)
+
+	var entry DashboardEntry
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, &entry))
+	condition := meta.FindStatusCondition(entry.Status.Conditions, ConditionAccepted)
+	require.NotNil(t, condition)
+	require.Equal(t, status, condition.Status)
+}
@@ -0,0 +1,256 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/reddec/ingress-dashboard/api"
+)
+
+const (
+	eventsCapacity  = 1024             // number of events kept for resuming
+	eventsKeepAlive = 15 * time.Second // interval of keep-alive comments in stream
+	eventIDScale    = 1000             // events sequence starts from start time in ms multiplied by this scale
+)
+
+func newEventLog(capacity int) *eventLog {
+	return &eventLog{
+		events: make([]api.Event, capacity),
+		// IDs are unique across restarts (unless more than 1000 events per ms generated), so clients
+		// resuming with ID from previous instance will get reset event instead of wrong events
+		lastID: uint64(time.Now().UnixNano()/int64(time.Millisecond)) * eventIDScale,
+		notify: make(chan struct{}),
+	}
+}
+
+// eventLog is bounded in-memory ring buffer of events with broadcast notifications.
+type eventLog struct {
+	lock   sync.RWMutex
+	events []api.Event
+	start  int // index of the oldest event
+	size   int // number of events in buffer
+	lastID uint64
+	notify chan struct{} // closed and replaced after each push
+}
+
+func (el *eventLog) push(events ...api.Event) {
+	if len(events) == 0 {
+		return
+	}
+	el.lock.Lock()
+	defer el.lock.Unlock()
+	for _, event := range events {
+		el.lastID++
+		event.ID = el.lastID
+		if el.size < len(el.events) {
+			el.events[(el.start+el.size)%len(el.events)] = event
+			el.size++
+		} else {
+			el.events[el.start] = event
+			el.start = (el.start + 1) % len(el.events)
+		}
+	}
+	close(el.notify)
+	el.notify = make(chan struct{})
+}
+
+// wait returns channel which will be closed after next push.
+func (el *eventLog) wait() <-chan struct{} {
+	el.lock.RLock()
+	defer el.lock.RUnlock()
+
+	return el.notify
+}
+
+func (el *eventLog) last() uint64 {
+	el.lock.RLock()
+	defer el.lock.RUnlock()
+
+	return el.lastID
+}
+
+// since returns events after specified ID. False returned if some events after ID are no longer available.
+func (el *eventLog) since(id uint64) ([]api.Event, bool) {
+	el.lock.RLock()
+	defer el.lock.RUnlock()
+	if id > el.lastID {
+		return nil, false
+	}
+	if id == el.lastID {
+		return nil, true
+	}
+	oldestID := el.lastID - uint64(el.size) + 1
+	if id+1 < oldestID {
+		return nil, false
+	}
+	var ans = make([]api.Event, 0, el.lastID-id)
+	for i := int(id + 1 - oldestID); i < el.size; i++ {
+		ans = append(ans, el.events[(el.start+i)%len(el.events)])
+	}
+
+	return ans, true
+}
+
+// diffIngresses computes events between two snapshots.
+func diffIngresses(old, current []Ingress, now time.Time) []api.Event {
+	var known = make(map[string]api.Ingress, len(old))
+	for _, ing := range old {
+		known[ing.UID] = toAPIIngress(ing)
+	}
+	var events []api.Event
+	for _, ing := range current {
+		item := toAPIIngress(ing)
+		previous, exists := known[ing.UID]
+		delete(known, ing.UID)
+		if !exists {
+			events = append(events, api.Event{Type: api.EventAdded, Time: now, Ingress: &item})
+
+			continue
+		}
+		if changes := diffFields(previous, item); len(changes) > 0 {
+			events = append(events, api.Event{Type: api.EventUpdated, Time: now, Ingress: &item, Changes: changes})
+		}
+	}
+	for _, ing := range old {
+		if item, removed := known[ing.UID]; removed {
+			item := item
+			events = append(events, api.Event{Type: api.EventRemoved, Time: now, Ingress: &item})
+		}
+	}
+
+	return events
+}
+
+func diffFields(old, current api.Ingress) []api.Change {
+	var changes []api.Change
+	check := func(field string, oldValue, newValue interface{}) {
+		if !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, api.Change{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+	check("title", old.Title, current.Title)
+	check("description", old.Description, current.Description)
+	check("logo_url", old.LogoURL, current.LogoURL)
+	check("class", old.Class, current.Class)
+	check("tags", nonEmptyList(old.Tags), nonEmptyList(current.Tags))
+	check("tls", old.TLS, current.TLS)
+	check("tls_status", old.TLSStatus, current.TLSStatus)
+	check("dead_refs", old.DeadRefs, current.DeadRefs)
+	check("refs", old.Refs, current.Refs)
+	check("cert", certState(old.Cert), certState(current.Cert))
+
+	return changes
+}
+
+// certState without time-dependent fields.
+func certState(cert *api.Cert) *api.Cert {
+	if cert == nil {
+		return nil
+	}
+	cp := *cert
+	cp.ExpiresIn = 0
+
+	return &cp
+}
+
+func nonEmptyList(list []string) []string {
+	if len(list) == 0 {
+		return nil
+	}
+
+	return list
+}
+
+// update snapshot of visible ingresses, generate events and record changes of catalog.
+func (svc *Service) update() {
+	svc.snapshotLock.Lock()
+	defer svc.snapshotLock.Unlock()
+	current := visibleIngresses(svc.getList())
+	now := time.Now()
+	svc.events.push(diffIngresses(svc.snapshot, current, now)...)
+	svc.snapshot = current
+	if !svc.health.Loading() { // partial snapshots should not be recorded as removals
+		if err := svc.changes.observe(current, now); err != nil {
+			log.Println("failed save changelog:", err)
+		}
+	}
+}
+
+func (svc *Service) apiEvents(writer http.ResponseWriter, request *http.Request, _ httprouter.Params) {
+	flusher, ok := writer.(http.Flusher)
+	if !ok {
+		writeJSON(writer, http.StatusInternalServerError, api.Error{Message: "streaming not supported"})
+
+		return
+	}
+
+	lastID := svc.events.last() // by default only new events
+	resumeID := request.Header.Get("Last-Event-ID")
+	if resumeID == "" {
+		resumeID = request.URL.Query().Get("last_event_id")
+	}
+	if resumeID != "" {
+		id, err := strconv.ParseUint(resumeID, 10, 64)
+		if err != nil {
+			writeJSON(writer, http.StatusBadRequest, api.Error{Message: "invalid last event ID"})
+
+			return
+		}
+		lastID = id
+	}
+
+	writer.Header().Set("Content-Type", "text/event-stream")
+	writer.Header().Set("Cache-Control", "no-cache")
+	writer.Header().Set("X-Accel-Buffering", "no") // disable buffering in nginx
+	writer.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(eventsKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		wait := svc.events.wait()
+		events, ok := svc.events.since(lastID)
+		if !ok {
+			lastID = svc.events.last()
+			events = []api.Event{{ID: lastID, Type: api.EventReset, Time: time.Now()}}
+		}
+		for _, event := range events {
+			if err := writeEvent(writer, event); err != nil {
+				log.Println("failed write event:", err)
+
+				return
+			}
+			lastID = event.ID
+		}
+		flusher.Flush()
+
+		select {
+		case <-request.Context().Done():
+			return
+		case <-wait:
+		case <-keepAlive.C:
+			if _, err := writer.Write([]byte(": keep-alive\n\n")); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func writeEvent(writer http.ResponseWriter, event api.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encode event: %w", err)
+	}
+	_, err = fmt.Fprintf(writer, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+
+	return err
+}
@@ -0,0 +1,127 @@
+package internal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/reddec/ingress-dashboard/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventLog(t *testing.T) {
+	log := newEventLog(3)
+	start := log.last()
+
+	events, ok := log.since(start)
+	require.True(t, ok)
+	require.Empty(t, events)
+
+	for i := 0; i < 4; i++ {
+		log.push(api.Event{Type: api.EventAdded})
+	}
+	require.Equal(t, start+4, log.last())
+
+	events, ok = log.since(start + 1)
+	require.True(t, ok)
+	require.Len(t, events, 3)
+	require.Equal(t, start+2, events[0].ID)
+	require.Equal(t, start+4, events[2].ID)
+
+	events, ok = log.since(start + 3)
+	require.True(t, ok)
+	require.Len(t, events, 1)
+
+	_, ok = log.since(start)
+	require.False(t, ok, "first event is no longer available")
+
+	_, ok = log.since(start + 10)
+	require.False(t, ok, "unknown future event")
+}
+
+func TestDiffIngresses(t *testing.T) {
+	now := time.Now()
+	a := Ingress{UID: "a", Name: "a", Refs: []Ref{{URL: "http://a", Pods: 1}}}
+	b := Ingress{UID: "b", Name: "b", TLS: true}
+	c := Ingress{UID: "c", Name: "c"}
+
+	changedA := a
+	changedA.Refs = []Ref{{URL: "http://a", Pods: 0}}
+	changedB := b
+	changedB.Cert = CertInfo{Expiration: now.Add(time.Hour), Issuer: "CA"}
+
+	events := diffIngresses([]Ingress{a, b, c}, []Ingress{changedA, changedB, {UID: "d", Name: "d"}}, now)
+	require.Len(t, events, 4)
+
+	require.Equal(t, api.EventUpdated, events[0].Type)
+	require.Equal(t, "a", events[0].Ingress.UID)
+	require.Equal(t, []string{"dead_refs", "refs"}, changedFields(events[0]))
+
+	require.Equal(t, api.EventUpdated, events[1].Type)
+	require.Equal(t, []string{"tls_status", "cert"}, changedFields(events[1]))
+
+	require.Equal(t, api.EventAdded, events[2].Type)
+	require.Equal(t, "d", events[2].Ingress.UID)
+
+	require.Equal(t, api.EventRemoved, events[3].Type)
+	require.Equal(t, "c", events[3].Ingress.UID)
+
+	require.Empty(t, diffIngresses([]Ingress{changedA, changedB}, []Ingress{changedA, changedB}, now.Add(time.Minute)))
+}
+
+func changedFields(event api.Event) []string {
+	var fields []string
+	for _, change := range event.Changes {
+		fields = append(fields, change.Field)
+	}
+
+	return fields
+}
+
+func TestService_events(t *testing.T) {
+	svc := New()
+	svc.Set([]Ingress{{UID: "a", Name: "a"}})
+	startID := svc.events.last()
+
+	server := httptest.NewServer(svc)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// resume from the first event: should receive only events after it
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/v1/events", nil)
+	require.NoError(t, err)
+	req.Header.Set("Last-Event-ID", strconv.FormatUint(startID-1, 10))
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+	stream := bufio.NewReader(res.Body)
+
+	svc.Set([]Ingress{{UID: "a", Name: "a", Title: "A"}})
+	svc.Set(nil)
+
+	var events []api.Event
+	for len(events) < 3 {
+		line, err := stream.ReadString('\n')
+		require.NoError(t, err)
+		if data := strings.TrimPrefix(line, "data: "); data != line {
+			var event api.Event
+			require.NoError(t, json.Unmarshal([]byte(data), &event))
+			events = append(events, event)
+		}
+	}
+	require.Equal(t, api.EventAdded, events[0].Type)
+	require.Equal(t, startID, events[0].ID)
+	require.Equal(t, api.EventUpdated, events[1].Type)
+	require.Equal(t, "title", events[1].Changes[0].Field)
+	require.Equal(t, api.EventRemoved, events[2].Type)
+}
@@ -0,0 +1,381 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/reddec/ingress-dashboard/internal/auth"
+	v13 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	favouritesCookie    = "favourites" // favourites of anonymous user
+	favouritesCookieAge = 365 * 24 * time.Hour
+	favouritesKey       = "favourites.json" // key in ConfigMap
+	favouritesTTL       = 10 * time.Second  // how long favourites (or error) from ConfigMap are cached
+	maxFavourites       = 100               // per user
+	maxCookieSize       = 3072              // of encoded favourites: browsers limit cookie (with attributes) by 4KB
+)
+
+var (
+	errCrossOrigin       = errors.New("cross-origin request")
+	errUnknownEntry      = errors.New("unknown entry")
+	errTooManyFavourites = fmt.Errorf("too many favourites, limit is %d", maxFavourites)
+	errCookieTooLarge    = errors.New("too many favourites to keep in cookie, sign in to add more")
+)
+
+// FavouritesStore keeps IDs (namespace.name) of favourite entries by user name.
+type FavouritesStore interface {
+	Favourites(ctx context.Context, user string) ([]string, error)
+	SetFavourites(ctx context.Context, user string, ids []string) error
+}
+
+// newMemoryFavourites is default store: favourites are lost after restart.
+func newMemoryFavourites() *memoryFavourites {
+	return &memoryFavourites{byUser: make(map[string][]string)}
+}
+
+type memoryFavourites struct {
+	lock   sync.RWMutex
+	byUser map[string][]string
+}
+
+func (mf *memoryFavourites) Favourites(_ context.Context, user string) ([]string, error) {
+	mf.lock.RLock()
+	defer mf.lock.RUnlock()
+
+	return mf.byUser[user], nil
+}
+
+func (mf *memoryFavourites) SetFavourites(_ context.Context, user string, ids []string) error {
+	mf.lock.Lock()
+	defer mf.lock.Unlock()
+	mf.set(user, ids)
+
+	return nil
+}
+
+func (mf *memoryFavourites) set(user string, ids []string) {
+	if len(ids) == 0 {
+		delete(mf.byUser, user)
+	} else {
+		mf.byUser[user] = ids
+	}
+}
+
+// NewFileFavourites creates store which keeps favourites of all users in JSON file. File will be created if needed.
+func NewFileFavourites(file string) (FavouritesStore, error) {
+	store := &fileFavourites{memoryFavourites: newMemoryFavourites(), file: file}
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read favourites: %w", err)
+	}
+	if err := json.Unmarshal(data, &store.byUser); err != nil {
+		return nil, fmt.Errorf("decode favourites: %w", err)
+	}
+
+	return store, nil
+}
+
+type fileFavourites struct {
+	*memoryFavourites
+	file string
+}
+
+func (ff *fileFavourites) SetFavourites(_ context.Context, user string, ids []string) error {
+	ff.lock.Lock()
+	defer ff.lock.Unlock()
+	ff.set(user, ids)
+	data, err := json.Marshal(ff.byUser)
+	if err != nil {
+		return fmt.Errorf("encode favourites: %w", err)
+	}
+	if err := writeFileAtomic(ff.file, data); err != nil {
+		return fmt.Errorf("save favourites: %w", err)
+	}
+
+	return nil
+}
+
+// NewConfigMapFavourites creates store which keeps favourites of all users in ConfigMap (as JSON), so they could be
+// shared between replicas. ConfigMap will be created if needed.
+func NewConfigMapFavourites(clientset kubernetes.Interface, namespace, name string) FavouritesStore {
+	return &configMapFavourites{
+		clientset: clientset,
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+type configMapFavourites struct {
+	clientset kubernetes.Interface
+	namespace string
+	name      string
+	write     sync.Mutex // serializes updates of ConfigMap
+	lock      sync.Mutex // protects cache, never held during requests to API
+	cache     map[string][]string
+	cacheErr  error
+	loaded    time.Time
+}
+
+func (cf *configMapFavourites) Favourites(ctx context.Context, user string) ([]string, error) {
+	cf.lock.Lock()
+	fresh := !cf.loaded.IsZero() && time.Since(cf.loaded) <= favouritesTTL
+	cache, cacheErr := cf.cache, cf.cacheErr
+	cf.lock.Unlock()
+	if fresh {
+		return cache[user], cacheErr
+	}
+
+	started := time.Now()
+	_, byUser, err := cf.load(ctx)
+	cf.store(started, byUser, err)
+
+	return byUser[user], err
+}
+
+// store loaded favourites in cache, unless they were replaced by more recent ones. Failures are cached as well,
+// so API server is not requested on every page view when ConfigMap is not available.
+func (cf *configMapFavourites) store(loaded time.Time, byUser map[string][]string, err error) {
+	cf.lock.Lock()
+	defer cf.lock.Unlock()
+	if loaded.Before(cf.loaded) {
+		return
+	}
+	cf.cache = byUser
+	cf.cacheErr = err
+	cf.loaded = loaded
+}
+
+func (cf *configMapFavourites) SetFavourites(ctx context.Context, user string, ids []string) error {
+	cf.write.Lock()
+	defer cf.write.Unlock()
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, byUser, err := cf.load(ctx)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			delete(byUser, user)
+		} else {
+			byUser[user] = ids
+		}
+		data, err := json.Marshal(byUser)
+		if err != nil {
+			return fmt.Errorf("encode favourites: %w", err)
+		}
+		if cm == nil {
+			cm = &v13.ConfigMap{ObjectMeta: v1.ObjectMeta{Name: cf.name, Namespace: cf.namespace}}
+			cm.Data = map[string]string{favouritesKey: string(data)}
+			_, err = cf.clientset.CoreV1().ConfigMaps(cf.namespace).Create(ctx, cm, v1.CreateOptions{})
+		} else {
+			if cm.Data == nil {
+				cm.Data = make(map[string]string)
+			}
+			cm.Data[favouritesKey] = string(data)
+			_, err = cf.clientset.CoreV1().ConfigMaps(cf.namespace).Update(ctx, cm, v1.UpdateOptions{})
+		}
+		if apierrors.IsAlreadyExists(err) {
+			return apierrors.NewConflict(v13.Resource("configmaps"), cf.name, err) // created concurrently, retry
+		}
+		if err != nil {
+			return fmt.Errorf("save favourites to ConfigMap: %w", err)
+		}
+		cf.store(time.Now(), byUser, nil)
+
+		return nil
+	})
+}
+
+// load ConfigMap and decoded favourites. Nil ConfigMap means it is not yet created.
+func (cf *configMapFavourites) load(ctx context.Context) (*v13.ConfigMap, map[string][]string, error) {
+	var byUser = make(map[string][]string)
+	cm, err := cf.clientset.CoreV1().ConfigMaps(cf.namespace).Get(ctx, cf.name, v1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, byUser, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("get ConfigMap with favourites: %w", err)
+	}
+	if data := cm.Data[favouritesKey]; data != "" {
+		if err := json.Unmarshal([]byte(data), &byUser); err != nil {
+			return nil, nil, fmt.Errorf("decode favourites: %w", err)
+		}
+	}
+
+	return cm, byUser, nil
+}
+
+// SetFavouritesStore replaces store of favourites for authorized users. By default, favourites are kept in memory.
+// Favourites of anonymous users are always kept in cookie.
+func (svc *Service) SetFavouritesStore(store FavouritesStore) {
+	svc.favourites = store
+}
+
+// favouritesOf user of request, errors are logged.
+func (svc *Service) favouritesOf(request *http.Request) []string {
+	user := auth.UserFromContext(request.Context())
+	if user == nil {
+		cookie, err := request.Cookie(favouritesCookie)
+		if err != nil {
+			return nil
+		}
+		values, err := url.ParseQuery(cookie.Value)
+		if err != nil {
+			return nil
+		}
+
+		return values["id"]
+	}
+	ids, err := svc.favourites.Favourites(request.Context(), user.Name)
+	if err != nil {
+		log.Println("failed get favourites of", user.Name, ":", err)
+	}
+
+	return ids
+}
+
+func (svc *Service) saveFavourites(writer http.ResponseWriter, request *http.Request, ids []string) error {
+	user := auth.UserFromContext(request.Context())
+	if user != nil {
+		return svc.favourites.SetFavourites(request.Context(), user.Name, ids)
+	}
+	http.SetCookie(writer, &http.Cookie{
+		Name:     favouritesCookie,
+		Value:    favouritesCookieValue(ids),
+		Path:     "/",
+		MaxAge:   int(favouritesCookieAge / time.Second),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return nil
+}
+
+func favouritesCookieValue(ids []string) string {
+	return url.Values{"id": ids}.Encode()
+}
+
+// postFavourites adds (form field add=<id>) or removes (remove=<id>) entry from favourites and redirects back.
+func (svc *Service) postFavourites(writer http.ResponseWriter, request *http.Request, _ httprouter.Params) {
+	if !sameOrigin(request) {
+		http.Error(writer, errCrossOrigin.Error(), http.StatusForbidden)
+
+		return
+	}
+	if err := request.ParseForm(); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+	ids := svc.favouritesOf(request)
+	if id := request.PostForm.Get("add"); id != "" {
+		if !svc.hasEntry(id) {
+			http.Error(writer, errUnknownEntry.Error(), http.StatusBadRequest)
+
+			return
+		}
+		if !contains(ids, id) {
+			if len(ids) >= maxFavourites {
+				http.Error(writer, errTooManyFavourites.Error(), http.StatusBadRequest)
+
+				return
+			}
+			ids = append(ids, id)
+			// browsers silently drop too large cookies, so all favourites would be lost
+			if auth.UserFromContext(request.Context()) == nil && len(favouritesCookieValue(ids)) > maxCookieSize {
+				http.Error(writer, errCookieTooLarge.Error(), http.StatusBadRequest)
+
+				return
+			}
+		}
+	}
+	if id := request.PostForm.Get("remove"); id != "" {
+		var kept = make([]string, 0, len(ids))
+		for _, fav := range ids {
+			if fav != id {
+				kept = append(kept, fav)
+			}
+		}
+		ids = kept
+	}
+	if err := svc.saveFavourites(writer, request, ids); err != nil {
+		log.Println("failed save favourites:", err)
+		http.Error(writer, "failed to save favourites", http.StatusInternalServerError)
+
+		return
+	}
+	http.Redirect(writer, request, backURL(request), http.StatusSeeOther)
+}
+
+func (svc *Service) hasEntry(id string) bool {
+	for _, ing := range visibleIngresses(svc.getList()) {
+		if ing.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// favouriteEntries in order of starring. Unknown and hidden entries are skipped.
+func favouriteEntries(list []Ingress, ids []string) []Ingress {
+	var byID = make(map[string]Ingress, len(list))
+	for _, ing := range list {
+		byID[ing.ID] = ing
+	}
+	var ans []Ingress
+	for _, id := range ids {
+		if ing, ok := byID[id]; ok {
+			ans = append(ans, ing)
+		}
+	}
+
+	return ans
+}
+
+// sameOrigin protects forms from cross-site requests: browsers always send Origin for POST requests.
+func sameOrigin(request *http.Request) bool {
+	if request.Header.Get("Sec-Fetch-Site") == "cross-site" {
+		return false
+	}
+	origin := request.Header.Get("Origin")
+	if origin == "" {
+		return true // old browsers and non-browser clients
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	server, _ := url.Parse(serverURL(request))
+
+	return u.Host == server.Host
+}
+
+// backURL is the page where form was submitted (if it's the same server) or index.
+func backURL(request *http.Request) string {
+	if referer, err := url.Parse(request.Referer()); err == nil && referer.IsAbs() {
+		if server, _ := url.Parse(serverURL(request)); referer.Host == server.Host {
+			return referer.RequestURI()
+		}
+	}
+
+	return "./"
+}
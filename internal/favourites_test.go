@@ -0,0 +1,164 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/reddec/ingress-dashboard/internal/auth"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestFileFavourites(t *testing.T) {
+	ctx := context.Background()
+	file := filepath.Join(t.TempDir(), "favourites.json")
+
+	store, err := NewFileFavourites(file)
+	require.NoError(t, err)
+	require.NoError(t, store.SetFavourites(ctx, "alice", []string{"monitoring.grafana"}))
+	require.NoError(t, store.SetFavourites(ctx, "bob", []string{"dev.grafana-dev"}))
+	require.NoError(t, store.SetFavourites(ctx, "bob", nil))
+
+	restored, err := NewFileFavourites(file)
+	require.NoError(t, err)
+	ids, err := restored.Favourites(ctx, "alice")
+	require.NoError(t, err)
+	require.Equal(t, []string{"monitoring.grafana"}, ids)
+	ids, err = restored.Favourites(ctx, "bob")
+	require.NoError(t, err)
+	require.Empty(t, ids)
+}
+
+func TestConfigMapFavourites(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset()
+
+	store := NewConfigMapFavourites(clientset, "ingress-dashboard", "favourites")
+	ids, err := store.Favourites(ctx, "alice")
+	require.NoError(t, err)
+	require.Empty(t, ids)
+
+	require.NoError(t, store.SetFavourites(ctx, "alice", []string{"monitoring.grafana"}))
+	require.NoError(t, store.SetFavourites(ctx, "bob", []string{"dev.grafana-dev"}))
+
+	// another replica
+	replica := NewConfigMapFavourites(clientset, "ingress-dashboard", "favourites")
+	ids, err = replica.Favourites(ctx, "alice")
+	require.NoError(t, err)
+	require.Equal(t, []string{"monitoring.grafana"}, ids)
+	ids, err = replica.Favourites(ctx, "bob")
+	require.NoError(t, err)
+	require.Equal(t, []string{"dev.grafana-dev"}, ids)
+}
+
+func TestConfigMapFavourites_errorCached(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewSimpleClientset()
+	var requests int
+	clientset.PrependReactor("get", "configmaps", func(k8stesting.Action) (bool, runtime.Object, error) {
+		requests++
+
+		return true, nil, errors.New("forbidden")
+	})
+
+	store := NewConfigMapFavourites(clientset, "ingress-dashboard", "favourites")
+	_, err := store.Favourites(ctx, "alice")
+	require.Error(t, err)
+	_, err = store.Favourites(ctx, "bob")
+	require.Error(t, err)
+	require.Equal(t, 1, requests)
+}
+
+func TestService_favourites(t *testing.T) {
+	svc := New()
+	svc.Set([]Ingress{
+		{UID: "grafana-dev", ID: "dev.grafana-dev", Name: "grafana-dev", Namespace: "dev", Refs: []Ref{{URL: "http://grafana.dev.example.com"}}},
+		{UID: "grafana", ID: "monitoring.grafana", Name: "grafana", Namespace: "monitoring", Refs: []Ref{{URL: "https://grafana.example.com"}}},
+	})
+
+	post := func(user *auth.User, cookies []*http.Cookie, form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/favourites", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Origin", "http://example.com")
+		req.Header.Set("Referer", "http://example.com/?group=namespace")
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		if user != nil {
+			req = req.WithContext(auth.WithUser(req.Context(), *user))
+		}
+		res := httptest.NewRecorder()
+		svc.ServeHTTP(res, req)
+
+		return res
+	}
+	index := func(user *auth.User, cookies []*http.Cookie) string {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		if user != nil {
+			req = req.WithContext(auth.WithUser(req.Context(), *user))
+		}
+		res := httptest.NewRecorder()
+		svc.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code)
+
+		return res.Body.String()
+	}
+
+	t.Run("anonymous user", func(t *testing.T) {
+		res := post(nil, nil, url.Values{"add": {"monitoring.grafana"}})
+		require.Equal(t, http.StatusSeeOther, res.Code)
+		require.Equal(t, "/?group=namespace", res.Header().Get("Location"))
+		cookies := res.Result().Cookies()
+		require.Len(t, cookies, 1)
+
+		body := index(nil, cookies)
+		require.Contains(t, body, `class="group favourites"`)
+		require.Contains(t, body, `name="remove" value="monitoring.grafana"`)
+		require.Contains(t, body, `name="add" value="dev.grafana-dev"`)
+
+		res = post(nil, cookies, url.Values{"remove": {"monitoring.grafana"}})
+		require.Equal(t, http.StatusSeeOther, res.Code)
+		require.NotContains(t, index(nil, res.Result().Cookies()), `class="group favourites"`)
+	})
+
+	t.Run("authorized user", func(t *testing.T) {
+		alice := &auth.User{Name: "alice"}
+		require.Equal(t, http.StatusSeeOther, post(alice, nil, url.Values{"add": {"dev.grafana-dev"}}).Code)
+		require.Contains(t, index(alice, nil), `class="group favourites"`)
+		require.NotContains(t, index(&auth.User{Name: "bob"}, nil), `class="group favourites"`)
+	})
+
+	t.Run("cookie size is limited", func(t *testing.T) {
+		var ids []string
+		for len(favouritesCookieValue(ids)) < maxCookieSize {
+			ids = append(ids, "default."+strings.Repeat("x", 60))
+		}
+		cookie := &http.Cookie{Name: favouritesCookie, Value: favouritesCookieValue(ids)}
+		require.Equal(t, http.StatusBadRequest, post(nil, []*http.Cookie{cookie}, url.Values{"add": {"monitoring.grafana"}}).Code)
+
+		alice := &auth.User{Name: "alice"}
+		require.Equal(t, http.StatusSeeOther, post(alice, []*http.Cookie{cookie}, url.Values{"add": {"monitoring.grafana"}}).Code)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, post(nil, nil, url.Values{"add": {"unknown"}}).Code)
+
+		req := httptest.NewRequest(http.MethodPost, "/favourites", strings.NewReader("add=monitoring.grafana"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Origin", "https://evil.example.org")
+		res := httptest.NewRecorder()
+		svc.ServeHTTP(res, req)
+		require.Equal(t, http.StatusForbidden, res.Code)
+	})
+}
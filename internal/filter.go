@@ -0,0 +1,160 @@
+package internal
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Filter of ingresses. Empty fields are ignored. Multiple values of the same field are combined by OR,
+// different fields are combined by AND.
+type Filter struct {
+	Namespaces []string    // exact namespace
+	Classes    []string    // exact ingress class
+	Tags       []string    // exact tag
+	TLS        []TLSStatus // TLS status
+	Dead       *bool       // has (or not) references without pods
+	Query      string      // case-insensitive words (all should match) in title, name, namespace, description, URLs or tags
+}
+
+// ParseFilter from query parameters: namespace, class, tag, tls, dead and q.
+func ParseFilter(values url.Values) Filter {
+	var filter = Filter{
+		Namespaces: nonEmpty(values["namespace"]),
+		Classes:    nonEmpty(values["class"]),
+		Tags:       nonEmpty(values["tag"]),
+		Query:      strings.TrimSpace(values.Get("q")),
+	}
+	for _, status := range nonEmpty(values["tls"]) {
+		filter.TLS = append(filter.TLS, TLSStatus(status))
+	}
+	if dead, err := strconv.ParseBool(values.Get("dead")); err == nil {
+		filter.Dead = &dead
+	}
+
+	return filter
+}
+
+// Apply filter to the list. Original list is not modified.
+func (filter Filter) Apply(list []Ingress) []Ingress {
+	var ans = make([]Ingress, 0, len(list))
+	for _, ing := range list {
+		if filter.Match(ing) {
+			ans = append(ans, ing)
+		}
+	}
+
+	return ans
+}
+
+// Match ingress by filter.
+func (filter Filter) Match(ingress Ingress) bool {
+	if len(filter.Namespaces) > 0 && !contains(filter.Namespaces, ingress.Namespace) {
+		return false
+	}
+	if len(filter.Classes) > 0 && !contains(filter.Classes, ingress.Class) {
+		return false
+	}
+	if len(filter.Tags) > 0 && !containsAny(filter.Tags, ingress.Tags) {
+		return false
+	}
+	if len(filter.TLS) > 0 && !containsTLS(filter.TLS, ingress.TLSStatus()) {
+		return false
+	}
+	if filter.Dead != nil && *filter.Dead != ingress.HasDeadRefs() {
+		return false
+	}
+	for _, term := range filter.terms() {
+		if !matchText(ingress, term) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Values is reverse of ParseFilter.
+func (filter Filter) Values() url.Values {
+	var values = make(url.Values)
+	if filter.Query != "" {
+		values.Set("q", filter.Query)
+	}
+	for _, ns := range filter.Namespaces {
+		values.Add("namespace", ns)
+	}
+	for _, class := range filter.Classes {
+		values.Add("class", class)
+	}
+	for _, tag := range filter.Tags {
+		values.Add("tag", tag)
+	}
+	for _, status := range filter.TLS {
+		values.Add("tls", string(status))
+	}
+	if filter.Dead != nil {
+		values.Set("dead", strconv.FormatBool(*filter.Dead))
+	}
+
+	return values
+}
+
+// IsEmpty returns true if filter matches everything.
+func (filter Filter) IsEmpty() bool {
+	return len(filter.Values()) == 0
+}
+
+// terms of query in lower case.
+func (filter Filter) terms() []string {
+	return strings.Fields(strings.ToLower(filter.Query))
+}
+
+func matchText(ingress Ingress, term string) bool {
+	for _, field := range textFields(ingress) {
+		if strings.Contains(strings.ToLower(field.value), term) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsAny(list []string, values []string) bool {
+	for _, value := range values {
+		if contains(list, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsTLS(list []TLSStatus, value TLSStatus) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func nonEmpty(values []string) []string {
+	var ans []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			ans = append(ans, v)
+		}
+	}
+
+	return ans
+}
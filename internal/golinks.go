@@ -0,0 +1,176 @@
+package internal
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/reddec/ingress-dashboard/internal/auth"
+)
+
+//nolint:gochecknoglobals
+var aliasPattern = regexp.MustCompile(`^[A-Za-z0-9._~-]+$`)
+
+func validAlias(alias string) bool {
+	return aliasPattern.MatchString(alias)
+}
+
+// GoLink is alias resolved to entry.
+type GoLink struct {
+	Alias    string
+	Explicit bool // defined by alias (annotation, static field), otherwise by name
+	Ingress  Ingress
+}
+
+// GoLinkConflict is alias defined by more than one entry. The first entry is used.
+type GoLinkConflict struct {
+	Alias     string
+	Explicit  bool
+	Shadowed  bool // some entries (listed after explicit ones) have the alias as name and can not be reached by it
+	Ingresses []Ingress
+}
+
+type goLinks struct {
+	links     map[string]GoLink // by lower-cased alias
+	conflicts []GoLinkConflict
+	invalid   []Ingress // entries with invalid alias, name used instead
+}
+
+// resolveGoLinks builds aliases for entries with links. Explicit aliases take precedence over names.
+// Aliases are case-insensitive.
+func resolveGoLinks(list []Ingress) goLinks {
+	var ans = goLinks{links: make(map[string]GoLink)}
+	var explicit, names = make(map[string][]Ingress), make(map[string][]Ingress)
+	var explicitOrder, namesOrder []string
+	for _, ing := range list {
+		if len(ing.Refs) == 0 {
+			continue
+		}
+		alias := ing.Alias
+		if alias != "" && !validAlias(alias) {
+			ans.invalid = append(ans.invalid, ing)
+			alias = ""
+		}
+		if alias != "" {
+			key := strings.ToLower(alias)
+			if _, ok := explicit[key]; !ok {
+				explicitOrder = append(explicitOrder, key)
+			}
+			explicit[key] = append(explicit[key], ing)
+
+			continue
+		}
+		if key := strings.ToLower(ing.Name); validAlias(key) {
+			if _, ok := names[key]; !ok {
+				namesOrder = append(namesOrder, key)
+			}
+			names[key] = append(names[key], ing)
+		}
+	}
+
+	for _, key := range explicitOrder {
+		ans.add(key, true, explicit[key], names[key])
+	}
+	for _, key := range namesOrder {
+		if _, shadowed := explicit[key]; shadowed {
+			continue
+		}
+		ans.add(key, false, names[key], nil)
+	}
+
+	return ans
+}
+
+// add link to the first entry. Shadowed are entries with the same name as explicit alias: they are reported as
+// conflict, but never used.
+func (gl *goLinks) add(alias string, explicit bool, ingresses, shadowed []Ingress) {
+	gl.links[alias] = GoLink{Alias: alias, Explicit: explicit, Ingress: ingresses[0]}
+	if len(ingresses)+len(shadowed) > 1 {
+		gl.conflicts = append(gl.conflicts, GoLinkConflict{
+			Alias:     alias,
+			Explicit:  explicit,
+			Shadowed:  len(shadowed) > 0,
+			Ingresses: append(append([]Ingress{}, ingresses...), shadowed...),
+		})
+	}
+}
+
+// sorted links by alias.
+func (gl *goLinks) sorted() []GoLink {
+	var ans = make([]GoLink, 0, len(gl.links))
+	for _, link := range gl.links {
+		ans = append(ans, link)
+	}
+	sort.Slice(ans, func(i, j int) bool {
+		return ans[i].Alias < ans[j].Alias
+	})
+
+	return ans
+}
+
+// UIAliasesContext is context for aliases page.
+type UIAliasesContext struct {
+	UIContext
+	Links     []GoLink
+	Conflicts []GoLinkConflict
+	Invalid   []Ingress
+}
+
+func (svc *Service) getGoLink(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	alias := params.ByName("alias")
+	link, ok := resolveGoLinks(visibleIngresses(svc.getList())).links[strings.ToLower(alias)]
+	if !ok {
+		// let user find what was meant
+		http.Redirect(writer, request, "/?"+url.Values{"q": {alias}}.Encode(), http.StatusFound)
+
+		return
+	}
+	http.Redirect(writer, request, goLinkTarget(link.Ingress.Refs[0].URL, params.ByName("rest"), request.URL.RawQuery), http.StatusFound)
+}
+
+func (svc *Service) getAliases(writer http.ResponseWriter, request *http.Request, _ httprouter.Params) {
+	locale := svc.locale(writer, request)
+	list := locale.localize(visibleIngresses(svc.getList()))
+	links := resolveGoLinks(list)
+	writer.Header().Set("Content-Type", "text/html")
+	if err := svc.aliases.Execute(writer, UIAliasesContext{
+		UIContext: UIContext{
+			Ingresses: list,
+			User:      auth.UserFromContext(request.Context()),
+			Problems:  countProblems(list),
+			Branding:  svc.branding,
+			Locale:    locale,
+			Locales:   svc.locales.list,
+		},
+		Links:     links.sorted(),
+		Conflicts: links.conflicts,
+		Invalid:   links.invalid,
+	}); err != nil {
+		log.Println("failed render aliases page:", err)
+	}
+}
+
+// goLinkTarget appends rest of path to path of the target URL and merges query.
+func goLinkTarget(target, rest, rawQuery string) string {
+	u, err := url.Parse(target)
+	if err != nil {
+		return target
+	}
+	if rest = strings.TrimPrefix(rest, "/"); rest != "" {
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + rest
+		u.RawPath = ""
+	}
+	if rawQuery != "" {
+		if u.RawQuery != "" {
+			u.RawQuery += "&" + rawQuery
+		} else {
+			u.RawQuery = rawQuery
+		}
+	}
+
+	return u.String()
+}
@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveGoLinks(t *testing.T) {
+	links := resolveGoLinks([]Ingress{
+		{UID: "docs", ID: "external.docs", Name: "docs", Alias: "Wiki", Refs: []Ref{{URL: "https://docs.example.com"}}},
+		{UID: "grafana-dev", ID: "dev.grafana", Name: "grafana", Refs: []Ref{{URL: "https://grafana.dev.example.com"}}},
+		{UID: "grafana", ID: "monitoring.grafana", Name: "grafana", Refs: []Ref{{URL: "https://grafana.example.com"}}},
+		{UID: "wiki", ID: "default.wiki", Name: "wiki", Alias: "wiki", Refs: []Ref{{URL: "https://wiki.example.com"}}},
+		{UID: "bad", ID: "default.bad", Name: "bad", Alias: "bad alias", Refs: []Ref{{URL: "https://bad.example.com"}}},
+		{UID: "docs-name", ID: "default.docs", Name: "docs", Refs: []Ref{{URL: "https://docs.internal"}}},
+		{UID: "empty", ID: "default.empty", Name: "empty"},
+		{UID: "wiki-name", ID: "external.wiki", Name: "Wiki", Refs: []Ref{{URL: "https://wiki.external.example.com"}}},
+	})
+
+	var aliases = make(map[string]string)
+	for alias, link := range links.links {
+		aliases[alias] = link.Ingress.UID
+	}
+	require.Equal(t, map[string]string{
+		"wiki":    "docs",
+		"grafana": "grafana-dev",
+		"bad":     "bad",
+		"docs":    "docs-name",
+	}, aliases)
+
+	require.Len(t, links.conflicts, 2)
+	require.Equal(t, "wiki", links.conflicts[0].Alias)
+	require.True(t, links.conflicts[0].Explicit)
+	require.True(t, links.conflicts[0].Shadowed)
+	require.Equal(t, []string{"docs", "wiki", "wiki-name"}, uids(links.conflicts[0].Ingresses))
+	require.Equal(t, "grafana", links.conflicts[1].Alias)
+	require.False(t, links.conflicts[1].Explicit)
+	require.False(t, links.conflicts[1].Shadowed)
+
+	require.Len(t, links.invalid, 1)
+	require.Equal(t, "bad", links.invalid[0].UID)
+}
+
+func TestService_getGoLink(t *testing.T) {
+	svc := New()
+	svc.Set([]Ingress{
+		{UID: "grafana", ID: "monitoring.grafana", Name: "grafana", Alias: "dashboards", Refs: []Ref{{URL: "https://grafana.example.com/"}}},
+		{UID: "search", ID: "default.search", Name: "search", Refs: []Ref{{URL: "https://search.example.com/?source=go"}}},
+		{UID: "app", ID: "default.app", Name: "app", Refs: []Ref{{URL: "https://app.example.com/app?tab=1#top"}}},
+		{UID: "dashboards", ID: "default.dashboards", Name: "dashboards", Refs: []Ref{{URL: "https://dashboards.example.com/"}}},
+	})
+
+	cases := map[string]string{
+		"/go/dashboards":                "https://grafana.example.com/",
+		"/go/Dashboards/":               "https://grafana.example.com/",
+		"/go/dashboards/d/abc?orgId=1":  "https://grafana.example.com/d/abc?orgId=1",
+		"/go/dashboards/explore%20this": "https://grafana.example.com/explore%20this",
+		"/go/search?q=term":             "https://search.example.com/?source=go&q=term",
+		"/go/app/sub?x=2":               "https://app.example.com/app/sub?tab=1&x=2#top",
+		"/go/grafana":                   "/?q=grafana",
+		"/go/unknown/path":              "/?q=unknown",
+	}
+	for path, location := range cases {
+		res := httptest.NewRecorder()
+		svc.ServeHTTP(res, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusFound, res.Code, path)
+		require.Equal(t, location, res.Header().Get("Location"), path)
+	}
+
+	res := httptest.NewRecorder()
+	svc.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/aliases", nil))
+	require.Equal(t, http.StatusOK, res.Code)
+	require.Contains(t, res.Body.String(), `<a href="go/dashboards"><code>dashboards</code></a>`)
+	require.Contains(t, res.Body.String(), `<a href="go/search"><code>search</code></a> <small>(name)</small>`)
+	require.Contains(t, res.Body.String(), `<td>alias and name</td>`)
+	require.Contains(t, res.Body.String(), `<a href="./problems"`, "problems badge")
+
+	t.Run("localized", func(t *testing.T) {
+		res := httptest.NewRecorder()
+		svc.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/aliases?lang=de", nil))
+		require.Equal(t, http.StatusOK, res.Code)
+		body := res.Body.String()
+		require.Contains(t, body, `<html lang="de">`)
+		require.Contains(t, body, `<h2>Go-Links</h2>`)
+		require.Contains(t, body, `<td>Alias und Name</td>`)
+		require.Contains(t, body, `<small>(Name)</small>`)
+		require.NotContains(t, body, "Conflicts")
+	})
+	require.Contains(t, res.Body.String(), `<a href="details/grafana">monitoring.grafana</a>, <a href="details/dashboards">default.dashboards</a>`)
+}
@@ -0,0 +1,195 @@
+package internal
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Group modes of index page, selected by group query parameter.
+const (
+	GroupNamespace = "namespace"
+	GroupClass     = "class"
+	GroupTag       = "tag"
+	GroupHost      = "host"
+)
+
+const groupParam = "group"
+
+// UIGroup is section of index page.
+type UIGroup struct {
+	Name      string
+	Other     bool // entries without value of group
+	Ingresses []Ingress
+}
+
+// ViewMode is link to the index page grouped by different mode (empty mode means no groups).
+type ViewMode struct {
+	Label  string
+	Mode   string
+	Active bool
+	URL    string
+}
+
+// parseGroup returns group mode or empty string if mode is not supported.
+func parseGroup(values url.Values) string {
+	switch mode := values.Get(groupParam); mode {
+	case GroupNamespace, GroupClass, GroupTag, GroupHost:
+		return mode
+	default:
+		return ""
+	}
+}
+
+// groupIngresses into sections sorted by name. Order of entries inside group is preserved. Entry could be in
+// several groups (tags and hosts). Entries without value are placed in the last group.
+func groupIngresses(list []Ingress, mode string) []UIGroup {
+	var groups = make(map[string]*UIGroup)
+	var rest = UIGroup{Name: fallbackGroup(mode), Other: true}
+	for _, ing := range list {
+		keys := groupKeys(ing, mode)
+		if len(keys) == 0 {
+			rest.Ingresses = append(rest.Ingresses, ing)
+
+			continue
+		}
+		for _, key := range keys {
+			group, ok := groups[key]
+			if !ok {
+				group = &UIGroup{Name: key}
+				groups[key] = group
+			}
+			group.Ingresses = append(group.Ingresses, ing)
+		}
+	}
+
+	var ans = make([]UIGroup, 0, len(groups)+1)
+	for _, group := range groups {
+		ans = append(ans, *group)
+	}
+	sort.Slice(ans, func(i, j int) bool {
+		return ans[i].Name < ans[j].Name
+	})
+	if len(rest.Ingresses) > 0 {
+		ans = append(ans, rest)
+	}
+
+	return ans
+}
+
+func groupKeys(ingress Ingress, mode string) []string {
+	switch mode {
+	case GroupNamespace:
+		if ingress.Namespace == "" {
+			return nil
+		}
+
+		return []string{ingress.Namespace}
+	case GroupClass:
+		if ingress.Class == "" {
+			return nil
+		}
+
+		return []string{ingress.Class}
+	case GroupTag:
+		return uniqueStrings(ingress.Tags)
+	case GroupHost:
+		return ingress.Hosts()
+	default:
+		return nil
+	}
+}
+
+func fallbackGroup(mode string) string {
+	switch mode {
+	case GroupNamespace:
+		return "without namespace"
+	case GroupClass:
+		return "default or static"
+	case GroupTag:
+		return "without tags"
+	case GroupHost:
+		return "without links"
+	default:
+		return ""
+	}
+}
+
+func uniqueStrings(list []string) []string {
+	var ans []string
+	for _, v := range list {
+		if !contains(ans, v) {
+			ans = append(ans, v)
+		}
+	}
+
+	return ans
+}
+
+// viewModes links with the same filter and sort order.
+func viewModes(filter Filter, active, sortValue string) []ViewMode {
+	var ans []ViewMode
+	for _, mode := range []string{"", GroupNamespace, GroupClass, GroupTag, GroupHost} {
+		values := filter.Values()
+		if sortValue != "" {
+			values.Set(sortParam, sortValue)
+		}
+		label := "flat"
+		if mode != "" {
+			values.Set(groupParam, mode)
+			label = mode
+		}
+		u := "./"
+		if encoded := values.Encode(); encoded != "" {
+			u += "?" + encoded
+		}
+		ans = append(ans, ViewMode{Label: label, Mode: mode, Active: mode == active, URL: u})
+	}
+
+	return ans
+}
+
+// viewParams are parameters of presentation (group, sort order, layout) which are not part of filter.
+// Empty values and default layout are skipped.
+func viewParams(group, sortValue, layout string) []QueryParam {
+	var ans []QueryParam
+	for _, param := range []QueryParam{{groupParam, group}, {sortParam, sortValue}, {layoutParam, layout}} {
+		if param.Value != "" && !(param.Name == layoutParam && param.Value == LayoutCards) {
+			ans = append(ans, param)
+		}
+	}
+
+	return ans
+}
+
+// keepView adds parameters of view to facets links, search form and reset link.
+func keepView(search UISearch, params []QueryParam) UISearch {
+	var query = make(url.Values, len(params))
+	for _, param := range params {
+		query.Set(param.Name, param.Value)
+	}
+	search.ResetURL = "./"
+	if len(query) == 0 {
+		return search
+	}
+	encoded := query.Encode()
+	search.ResetURL += "?" + encoded
+	search.Params = append(search.Params, params...)
+	facets := make([]Facet, 0, len(search.Facets))
+	for _, facet := range search.Facets {
+		values := make([]FacetValue, 0, len(facet.Values))
+		for _, value := range facet.Values {
+			if strings.Contains(value.URL, "?") {
+				value.URL += "&" + encoded
+			} else {
+				value.URL += "?" + encoded
+			}
+			values = append(values, value)
+		}
+		facet.Values = values
+		facets = append(facets, facet)
+	}
+	search.Facets = facets
+
+	return search
+}
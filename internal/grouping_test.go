@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupIngresses(t *testing.T) {
+	list := []Ingress{
+		{UID: "docs", Namespace: "external", Static: true},
+		{UID: "grafana-dev", Namespace: "dev", Class: "nginx", Refs: []Ref{{URL: "http://grafana.dev.example.com"}}},
+		{UID: "grafana", Namespace: "monitoring", Class: "nginx", Refs: []Ref{
+			{URL: "https://grafana.example.com"},
+			{URL: "https://grafana.example.com/admin"},
+			{URL: "https://shared.example.com"},
+		}},
+		{UID: "prometheus", Namespace: "monitoring", Class: "traefik", Refs: []Ref{
+			{URL: "http://prometheus.example.com"},
+			{URL: "https://Shared.example.com/prometheus"},
+		}},
+	}
+
+	names := func(groups []UIGroup) map[string][]string {
+		var ans = make(map[string][]string)
+		for _, group := range groups {
+			ans[group.Name] = uids(group.Ingresses)
+		}
+
+		return ans
+	}
+
+	t.Run("namespace", func(t *testing.T) {
+		groups := groupIngresses(list, GroupNamespace)
+		require.Equal(t, []string{"dev", "external", "monitoring"}, []string{groups[0].Name, groups[1].Name, groups[2].Name})
+		require.Equal(t, []string{"grafana", "prometheus"}, uids(groups[2].Ingresses))
+	})
+
+	t.Run("class", func(t *testing.T) {
+		require.Equal(t, map[string][]string{
+			"nginx":             {"grafana-dev", "grafana"},
+			"traefik":           {"prometheus"},
+			"default or static": {"docs"},
+		}, names(groupIngresses(list, GroupClass)))
+		require.Equal(t, "default or static", groupIngresses(list, GroupClass)[2].Name, "fallback group is last")
+	})
+
+	t.Run("host", func(t *testing.T) {
+		require.Equal(t, map[string][]string{
+			"grafana.dev.example.com": {"grafana-dev"},
+			"grafana.example.com":     {"grafana"},
+			"prometheus.example.com":  {"prometheus"},
+			"shared.example.com":      {"grafana", "prometheus"},
+			"without links":           {"docs"},
+		}, names(groupIngresses(list, GroupHost)))
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		require.Empty(t, parseGroup(url.Values{"group": {"color"}}))
+	})
+}
+
+func TestService_indexGroups(t *testing.T) {
+	svc := New()
+	svc.Set([]Ingress{
+		{UID: "grafana-dev", Name: "grafana-dev", Namespace: "dev", Refs: []Ref{{URL: "http://grafana.dev.example.com"}}},
+		{UID: "grafana", Name: "grafana", Namespace: "monitoring", Refs: []Ref{{URL: "https://grafana.example.com"}}},
+		{UID: "prometheus", Name: "prometheus", Namespace: "monitoring", Refs: []Ref{{URL: "http://prometheus.example.com"}}},
+	})
+
+	res := httptest.NewRecorder()
+	svc.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/?group=namespace&namespace=monitoring", nil))
+	require.Equal(t, http.StatusOK, res.Code)
+	body := res.Body.String()
+	require.Contains(t, body, "<summary>monitoring <small>2</small></summary>")
+	require.NotContains(t, body, "<summary>dev")
+	require.Contains(t, body, `<input type="hidden" name="group" value="namespace">`)
+	require.Contains(t, body, `href="./?namespace=monitoring&amp;namespace=dev&amp;group=namespace"`)
+	require.Contains(t, body, `<a href="./?group=tag&amp;namespace=monitoring">tag</a>`)
+}
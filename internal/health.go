@@ -0,0 +1,175 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// Names of sources tracked by Health.
+const (
+	SourceStatic    = "static"
+	SourceIngresses = "ingresses"
+	SourceEntries   = "dashboardentries"
+)
+
+const (
+	watchErrorTTL     = 2 * syncInterval // watch error considered as actual during this time
+	livenessThreshold = 5 * time.Minute  // continuous watch errors during this time fail liveness probe
+)
+
+// NewHealth creates empty tracker of sources state.
+func NewHealth() *Health {
+	return &Health{sources: make(map[string]*sourceState)}
+}
+
+// Health tracks state of data sources: initial sync and watch errors. Nil Health ignores all updates.
+type Health struct {
+	lock    sync.RWMutex
+	order   []string
+	sources map[string]*sourceState
+}
+
+type sourceState struct {
+	synced       bool
+	err          error
+	lastError    time.Time // last time of error
+	failingSince time.Time // first time of continuous errors
+}
+
+// failing returns true if source had recent error.
+func (ss *sourceState) failing(now time.Time) bool {
+	return ss.err != nil && now.Sub(ss.lastError) < watchErrorTTL
+}
+
+// Register source as pending. Readiness fails till all registered sources are synced.
+func (h *Health) Register(name string) {
+	if h == nil {
+		return
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if _, exists := h.sources[name]; exists {
+		return
+	}
+	h.sources[name] = &sourceState{}
+	h.order = append(h.order, name)
+}
+
+// Synced marks source as completely loaded. Unregistered source will be registered.
+func (h *Health) Synced(name string) {
+	if h == nil {
+		return
+	}
+	h.Register(name)
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.sources[name].synced = true
+}
+
+// Failed reports error of source loading or watching. Errors are considered as resolved after some time without new errors.
+func (h *Health) Failed(name string, err error) {
+	if h == nil {
+		return
+	}
+	h.Register(name)
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	state := h.sources[name]
+	now := time.Now()
+	if !state.failing(now) {
+		state.failingSince = now
+	}
+	state.err = err
+	state.lastError = now
+}
+
+// Loading returns true till all registered sources are synced.
+func (h *Health) Loading() bool {
+	if h == nil {
+		return false
+	}
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	for _, state := range h.sources {
+		if !state.synced {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Ready returns true if all sources are synced and has no recent errors. Report contains line per source.
+func (h *Health) Ready() (bool, []string) {
+	return h.check(func(state *sourceState, now time.Time) string {
+		switch {
+		case !state.synced && state.err != nil:
+			return fmt.Sprintf("not synced: %v", state.err)
+		case !state.synced:
+			return "not synced"
+		case state.failing(now):
+			return fmt.Sprintf("watch failed: %v", state.err)
+		default:
+			return ""
+		}
+	})
+}
+
+// Live returns false if any source failing for too long. Report contains line per source.
+func (h *Health) Live() (bool, []string) {
+	return h.check(func(state *sourceState, now time.Time) string {
+		if state.failing(now) && now.Sub(state.failingSince) >= livenessThreshold {
+			return fmt.Sprintf("failing since %s: %v", state.failingSince.Format(time.RFC3339), state.err)
+		}
+
+		return ""
+	})
+}
+
+func (h *Health) check(problem func(state *sourceState, now time.Time) string) (bool, []string) {
+	if h == nil {
+		return true, nil
+	}
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	now := time.Now()
+	ok := true
+	report := make([]string, 0, len(h.order))
+	for _, name := range h.order {
+		if reason := problem(h.sources[name], now); reason != "" {
+			ok = false
+			report = append(report, "[-]"+name+" "+reason)
+		} else {
+			report = append(report, "[+]"+name+" ok")
+		}
+	}
+
+	return ok, report
+}
+
+func (svc *Service) getHealthz(writer http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	writeHealth(writer, "healthz", svc.health.Live)
+}
+
+func (svc *Service) getReadyz(writer http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	writeHealth(writer, "readyz", svc.health.Ready)
+}
+
+func writeHealth(writer http.ResponseWriter, name string, check func() (bool, []string)) {
+	ok, report := check()
+	writer.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	writer.Header().Set("Cache-Control", "no-cache")
+	if ok {
+		report = append(report, name+" check passed")
+		writer.WriteHeader(http.StatusOK)
+	} else {
+		report = append(report, name+" check failed")
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_, _ = writer.Write([]byte(strings.Join(report, "\n") + "\n"))
+}
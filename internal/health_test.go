@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealth(t *testing.T) {
+	svc := New()
+	health := svc.Health()
+	health.Register(SourceStatic)
+	health.Register(SourceIngresses)
+
+	check := func(path string, code int, contains ...string) {
+		t.Helper()
+		res := httptest.NewRecorder()
+		svc.ServeHTTP(res, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, code, res.Code)
+		for _, text := range contains {
+			require.Contains(t, res.Body.String(), text)
+		}
+	}
+
+	health.Synced(SourceStatic)
+	require.True(t, health.Loading())
+	check("/readyz", http.StatusServiceUnavailable, "[+]static ok", "[-]ingresses not synced", "readyz check failed")
+	check("/healthz", http.StatusOK, "healthz check passed")
+	check("/", http.StatusOK, "Loading cluster data")
+
+	health.Synced(SourceIngresses)
+	require.False(t, health.Loading())
+	check("/readyz", http.StatusOK, "[+]ingresses ok", "readyz check passed")
+
+	health.Failed(SourceIngresses, errors.New("connection refused")) //nolint:goerr113
+	check("/readyz", http.StatusServiceUnavailable, "[-]ingresses watch failed: connection refused")
+	check("/healthz", http.StatusOK)
+
+	// continuous errors for too long
+	health.sources[SourceIngresses].failingSince = time.Now().Add(-livenessThreshold)
+	check("/healthz", http.StatusServiceUnavailable, "[-]ingresses failing since")
+
+	// errors are resolved after some time
+	health.sources[SourceIngresses].lastError = time.Now().Add(-watchErrorTTL)
+	check("/readyz", http.StatusOK)
+	check("/healthz", http.StatusOK)
+}
@@ -0,0 +1,318 @@
+package internal
+
+import (
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hako/durafmt"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultLanguage = "en"
+	langParam       = "lang" // query parameter and cookie with selected language
+	langCookieAge   = 365 * 24 * time.Hour
+	untilUnits      = 2 // number of units in localized durations
+)
+
+// Plural forms of messages. English and German use only one and other, Russian uses one, few and many.
+const (
+	pluralOne   = "one"
+	pluralFew   = "few"
+	pluralMany  = "many"
+	pluralOther = "other"
+)
+
+// message is plain string or map of plural forms.
+type message map[string]string
+
+func (msg *message) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*msg = message{pluralOther: value.Value}
+
+		return nil
+	}
+	var forms map[string]string
+	if err := value.Decode(&forms); err != nil {
+		return fmt.Errorf("decode plural forms: %w", err)
+	}
+	*msg = forms
+
+	return nil
+}
+
+type catalog struct {
+	Name       string             `yaml:"name"`        // native name of language
+	DateLayout string             `yaml:"date_layout"` // Go layout of dates
+	Units      string             `yaml:"units"`       // durafmt units
+	Messages   map[string]message `yaml:"messages"`
+}
+
+// Locale of UI. Missing messages are taken from fallback locale (English).
+type Locale struct {
+	Lang       string // language code (en, de, ...)
+	Name       string // native name of language
+	messages   map[string]message
+	dateLayout string
+	units      durafmt.Units
+	fallback   *Locale
+}
+
+// T returns message by key formatted by arguments (as in fmt.Sprintf). Unknown keys are returned as-is.
+func (l *Locale) T(key string, args ...interface{}) string {
+	msg, ok := l.lookup(key)
+	if !ok {
+		return key
+	}
+	text := msg[pluralOther]
+	if len(args) == 0 {
+		return text
+	}
+
+	return fmt.Sprintf(text, args...)
+}
+
+// N returns plural form of message for the number. The number is the first argument of format.
+func (l *Locale) N(key string, n int, args ...interface{}) string {
+	msg, ok := l.lookup(key)
+	if !ok {
+		return key
+	}
+	text, ok := msg[pluralForm(l.Lang, n)]
+	if !ok {
+		text = msg[pluralOther]
+	}
+
+	return fmt.Sprintf(text, append([]interface{}{n}, args...)...)
+}
+
+// Until returns human-readable duration till the time.
+func (l *Locale) Until(t time.Time) string {
+	return durafmt.Parse(time.Until(t).Truncate(time.Second)).LimitFirstN(untilUnits).Format(l.units)
+}
+
+// Date in local format.
+func (l *Locale) Date(t time.Time) string {
+	return t.Format(l.dateLayout)
+}
+
+func (l *Locale) lookup(key string) (message, bool) {
+	if msg, ok := l.messages[key]; ok {
+		return msg, true
+	}
+	if l.fallback != nil {
+		return l.fallback.lookup(key)
+	}
+
+	return nil, false
+}
+
+// localize entries: description in the language of locale is used if defined.
+func (l *Locale) localize(list []Ingress) []Ingress {
+	var ans = make([]Ingress, 0, len(list))
+	for _, ing := range list {
+		if description, ok := ing.Descriptions[l.Lang]; ok {
+			ing.Description = description
+		}
+		ans = append(ans, ing)
+	}
+
+	return ans
+}
+
+// translate labels generated by server: facets, views, layouts, columns and groups.
+func (l *Locale) translate(ui UIContext) UIContext {
+	var facets = make([]Facet, 0, len(ui.Search.Facets))
+	for _, facet := range ui.Search.Facets {
+		facet.Title = l.T("facet_" + facet.Param)
+		if facet.Param == facetTLS || facet.Param == facetDead {
+			var values = make([]FacetValue, 0, len(facet.Values))
+			for _, value := range facet.Values {
+				value.Label = l.T("facet_" + facet.Param + "_" + value.Value)
+				values = append(values, value)
+			}
+			facet.Values = values
+		}
+		facets = append(facets, facet)
+	}
+	ui.Search.Facets = facets
+
+	var views = make([]ViewMode, 0, len(ui.Views))
+	for _, view := range ui.Views {
+		if view.Mode == "" {
+			view.Label = l.T("group_flat")
+		} else {
+			view.Label = l.T("group_" + view.Mode)
+		}
+		views = append(views, view)
+	}
+	ui.Views = views
+
+	var layouts = make([]ViewMode, 0, len(ui.Layouts))
+	for _, mode := range ui.Layouts {
+		mode.Label = l.T("layout_" + mode.Mode)
+		layouts = append(layouts, mode)
+	}
+	ui.Layouts = layouts
+
+	var columns = make([]UIColumn, 0, len(ui.Columns))
+	for _, column := range ui.Columns {
+		column.Label = l.T("column_" + column.Key)
+		columns = append(columns, column)
+	}
+	ui.Columns = columns
+
+	var groups = make([]UIGroup, 0, len(ui.Groups))
+	for _, group := range ui.Groups {
+		if group.Other {
+			group.Name = l.T("group_other_" + ui.Group)
+		}
+		groups = append(groups, group)
+	}
+	ui.Groups = groups
+
+	return ui
+}
+
+// pluralForm of number by CLDR rules for supported languages.
+func pluralForm(lang string, n int) string {
+	if n < 0 {
+		n = -n
+	}
+	switch lang {
+	case "ru", "uk", "be":
+		mod10, mod100 := n%10, n%100
+		switch {
+		case mod10 == 1 && mod100 != 11:
+			return pluralOne
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return pluralFew
+		default:
+			return pluralMany
+		}
+	default:
+		if n == 1 {
+			return pluralOne
+		}
+
+		return pluralOther
+	}
+}
+
+// loadLocales from catalogs <lang>.yaml. English catalog is required and used as fallback.
+func loadLocales(catalogs fs.FS) (*locales, error) {
+	files, err := fs.Glob(catalogs, "*.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("list catalogs: %w", err)
+	}
+	var ans = &locales{byLang: make(map[string]*Locale)}
+	for _, file := range files {
+		data, err := fs.ReadFile(catalogs, file)
+		if err != nil {
+			return nil, fmt.Errorf("read catalog %s: %w", file, err)
+		}
+		var c catalog
+		if err := yaml.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("parse catalog %s: %w", file, err)
+		}
+		units, err := durafmt.DefaultUnitsCoder.Decode(c.Units)
+		if err != nil {
+			return nil, fmt.Errorf("parse units in catalog %s: %w", file, err)
+		}
+		locale := &Locale{
+			Lang:       strings.TrimSuffix(path.Base(file), ".yaml"),
+			Name:       c.Name,
+			messages:   c.Messages,
+			dateLayout: c.DateLayout,
+			units:      units,
+		}
+		ans.byLang[locale.Lang] = locale
+		ans.list = append(ans.list, locale)
+	}
+	fallback, ok := ans.byLang[defaultLanguage]
+	if !ok {
+		return nil, fmt.Errorf("catalog %s.yaml is required", defaultLanguage) //nolint:goerr113
+	}
+	for _, locale := range ans.list {
+		if locale != fallback {
+			locale.fallback = fallback
+		}
+	}
+	sort.Slice(ans.list, func(i, j int) bool {
+		return ans.list[i].Lang < ans.list[j].Lang
+	})
+	ans.fallback = fallback
+
+	return ans, nil
+}
+
+type locales struct {
+	byLang   map[string]*Locale
+	list     []*Locale // sorted by language
+	fallback *Locale
+}
+
+// negotiate locale by Accept-Language header. Only primary language subtag is used (de-AT means de).
+func (ls *locales) negotiate(acceptLanguage string) *Locale {
+	type candidate struct {
+		locale  *Locale
+		quality float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		params := strings.Split(part, ";")
+		lang := strings.ToLower(strings.TrimSpace(params[0]))
+		lang = strings.SplitN(lang, "-", 2)[0] //nolint:gomnd
+		quality := 1.0
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if locale, ok := ls.byLang[lang]; ok && quality > 0 {
+			candidates = append(candidates, candidate{locale: locale, quality: quality})
+		}
+	}
+	if len(candidates) == 0 {
+		return ls.fallback
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	return candidates[0].locale
+}
+
+// locale for request: explicitly selected by lang query parameter (remembered in cookie), from cookie
+// or negotiated by Accept-Language.
+func (svc *Service) locale(writer http.ResponseWriter, request *http.Request) *Locale {
+	writer.Header().Add("Vary", "Accept-Language, Cookie")
+	if locale, ok := svc.locales.byLang[request.URL.Query().Get(langParam)]; ok {
+		http.SetCookie(writer, &http.Cookie{
+			Name:     langParam,
+			Value:    locale.Lang,
+			Path:     "/",
+			MaxAge:   int(langCookieAge / time.Second),
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		})
+
+		return locale
+	}
+	if cookie, err := request.Cookie(langParam); err == nil {
+		if locale, ok := svc.locales.byLang[cookie.Value]; ok {
+			return locale
+		}
+	}
+
+	return svc.locales.negotiate(request.Header.Get("Accept-Language"))
+}
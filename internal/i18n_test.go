@@ -0,0 +1,109 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/reddec/ingress-dashboard/internal/static"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadLocales(t *testing.T) {
+	ls, err := loadLocales(static.LocalesFS())
+	require.NoError(t, err)
+	require.Equal(t, defaultLanguage, ls.fallback.Lang)
+
+	keys := func(locale *Locale) []string {
+		var ans []string
+		for key := range locale.messages {
+			ans = append(ans, key)
+		}
+		sort.Strings(ans)
+
+		return ans
+	}
+
+	for _, locale := range ls.list {
+		require.NotEmpty(t, locale.Name, locale.Lang)
+		require.Equal(t, keys(ls.fallback), keys(locale), "catalog %s", locale.Lang)
+	}
+}
+
+func TestLocale_N(t *testing.T) {
+	ls, err := loadLocales(static.LocalesFS())
+	require.NoError(t, err)
+
+	en := ls.byLang["en"]
+	require.Equal(t, "1 host", en.N("hosts", 1))
+	require.Equal(t, "2 hosts", en.N("hosts", 2))
+
+	ru := ls.byLang["ru"]
+	require.Equal(t, "1 хост", ru.N("hosts", 1))
+	require.Equal(t, "3 хоста", ru.N("hosts", 3))
+	require.Equal(t, "5 хостов", ru.N("hosts", 5))
+	require.Equal(t, "11 хостов", ru.N("hosts", 11))
+	require.Equal(t, "21 хост", ru.N("hosts", 21))
+
+	require.Equal(t, "unknown_key", ru.T("unknown_key"))
+}
+
+func TestLocales_negotiate(t *testing.T) {
+	ls, err := loadLocales(static.LocalesFS())
+	require.NoError(t, err)
+
+	require.Equal(t, "en", ls.negotiate("").Lang)
+	require.Equal(t, "en", ls.negotiate("fr-FR, ja").Lang)
+	require.Equal(t, "de", ls.negotiate("de-AT").Lang)
+	require.Equal(t, "ru", ls.negotiate("fr;q=0.9, de;q=0.5, ru;q=0.8").Lang)
+	require.Equal(t, "en", ls.negotiate("de;q=0, en;q=0.1").Lang)
+}
+
+func TestService_locale(t *testing.T) {
+	svc, err := NewWithOptions(Options{})
+	require.NoError(t, err)
+	svc.Set([]Ingress{{
+		UID:          "grafana",
+		Name:         "grafana",
+		Namespace:    "monitoring",
+		Description:  "Dashboards",
+		Descriptions: map[string]string{"de": "Übersichten"},
+		Refs:         []Ref{{URL: "https://grafana.example.com", Pods: 1}},
+	}})
+
+	t.Run("accept-language", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Language", "de-DE,de;q=0.9,en;q=0.8")
+		res := httptest.NewRecorder()
+		svc.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code)
+		body := res.Body.String()
+		require.Contains(t, body, `<html lang="de">`)
+		require.Contains(t, body, `1 Host`)
+		require.Contains(t, body, `Übersichten`)
+		require.NotContains(t, body, `Dashboards`)
+		require.Contains(t, res.Header().Values("Vary"), "Accept-Language, Cookie")
+	})
+
+	t.Run("explicit language is remembered", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/details/grafana?lang=ru", nil)
+		req.Header.Set("Accept-Language", "de")
+		res := httptest.NewRecorder()
+		svc.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code)
+		require.Contains(t, res.Body.String(), `<html lang="ru">`)
+		require.Contains(t, res.Body.String(), `Dashboards`)
+
+		cookies := res.Result().Cookies()
+		require.Len(t, cookies, 1)
+		require.Equal(t, "ru", cookies[0].Value)
+
+		req = httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Language", "de")
+		req.AddCookie(cookies[0])
+		res = httptest.NewRecorder()
+		svc.ServeHTTP(res, req)
+		require.Contains(t, res.Body.String(), `<html lang="ru">`)
+	})
+}
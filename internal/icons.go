@@ -0,0 +1,136 @@
+package internal
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	iconMaxSize     = 64 * 1024      // bigger icons are ignored
+	iconTTL         = 24 * time.Hour // how long fetched icon is cached
+	iconFailedTTL   = time.Hour      // how long failed fetch is cached
+	iconFetchers    = 8              // parallel downloads
+	iconsFetchLimit = 10 * time.Second
+)
+
+func newIconCache() *iconCache {
+	return &iconCache{icons: make(map[string]cachedIcon)}
+}
+
+// iconCache downloads icons and keeps them as data URIs.
+type iconCache struct {
+	lock  sync.Mutex
+	icons map[string]cachedIcon
+}
+
+type cachedIcon struct {
+	dataURI string // empty if failed
+	expires time.Time
+}
+
+// get data URIs of icons by URLs. Missed icons are fetched in parallel. Icons which could not be fetched are not
+// included in result.
+func (ic *iconCache) get(ctx context.Context, urls []string) map[string]string {
+	ctx, cancel := context.WithTimeout(ctx, iconsFetchLimit)
+	defer cancel()
+
+	var ans = make(map[string]string, len(urls))
+	var missed []string
+	now := time.Now()
+	ic.lock.Lock()
+	for _, u := range urls {
+		if _, ok := ans[u]; ok {
+			continue
+		}
+		if icon, ok := ic.icons[u]; ok && now.Before(icon.expires) {
+			if icon.dataURI != "" {
+				ans[u] = icon.dataURI
+			}
+
+			continue
+		}
+		ans[u] = ""
+		missed = append(missed, u)
+	}
+	ic.lock.Unlock()
+
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	var queue = make(chan string)
+	for i := 0; i < iconFetchers && i < len(missed); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for u := range queue {
+				dataURI := fetchIcon(ctx, u)
+				if dataURI != "" || ctx.Err() == nil { // do not remember failures caused by timeout
+					ic.store(u, dataURI)
+				}
+				lock.Lock()
+				ans[u] = dataURI
+				lock.Unlock()
+			}
+		}()
+	}
+	for _, u := range missed {
+		queue <- u
+	}
+	close(queue)
+	wg.Wait()
+
+	for u, dataURI := range ans {
+		if dataURI == "" {
+			delete(ans, u)
+		}
+	}
+
+	return ans
+}
+
+func (ic *iconCache) store(u string, dataURI string) {
+	ttl := iconTTL
+	if dataURI == "" {
+		ttl = iconFailedTTL
+	}
+	ic.lock.Lock()
+	defer ic.lock.Unlock()
+	ic.icons[u] = cachedIcon{dataURI: dataURI, expires: time.Now().Add(ttl)}
+}
+
+// fetchIcon as data URI. Returns empty string if icon is not available, too big or not an image.
+func fetchIcon(ctx context.Context, u string) string {
+	if !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+		return ""
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return ""
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return ""
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return ""
+	}
+	data, err := io.ReadAll(io.LimitReader(res.Body, iconMaxSize+1))
+	if err != nil || len(data) == 0 || len(data) > iconMaxSize {
+		return ""
+	}
+	contentType, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type"))
+	if !strings.HasPrefix(contentType, "image/") {
+		contentType = http.DetectContentType(data)
+	}
+	if !strings.HasPrefix(contentType, "image/") {
+		return ""
+	}
+
+	return "data:" + contentType + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
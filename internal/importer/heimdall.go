@@ -0,0 +1,63 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/reddec/ingress-dashboard/internal"
+)
+
+const heimdallTagType = 1 // items with this type are tags, not applications
+
+type heimdallItem struct {
+	Title          string `json:"title"`
+	URL            string `json:"url"`
+	Description    string `json:"description"`
+	AppDescription string `json:"appdescription"`
+	Icon           string `json:"icon"`
+	Type           int    `json:"type"`
+}
+
+func importHeimdall(reader io.Reader, opts Options) ([]internal.Ingress, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("read heimdall export: %w", err)
+	}
+
+	// export could be plain list of items or object with items
+	var items []heimdallItem
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '{' {
+		var wrapper struct {
+			Items []heimdallItem `json:"items"`
+		}
+		err = json.Unmarshal(content, &wrapper)
+		items = wrapper.Items
+	} else {
+		err = json.Unmarshal(content, &items)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decode heimdall export: %w", err)
+	}
+
+	var ans []internal.Ingress
+	for _, item := range items {
+		if item.Type == heimdallTagType {
+			continue
+		}
+		description := item.AppDescription
+		if description == "" && !strings.HasPrefix(strings.TrimSpace(item.Description), "{") {
+			// enhanced apps keep JSON config in description
+			description = item.Description
+		}
+		icon := item.Icon
+		if icon != "" && !strings.Contains(icon, "://") {
+			icon = "storage/" + strings.TrimLeft(icon, "/") // uploaded icons are served from storage
+		}
+		ans = append(ans, newIngress("", item.Title, description, icon, opts, item.URL))
+	}
+
+	return ans, nil
+}
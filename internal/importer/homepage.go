@@ -0,0 +1,58 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/reddec/ingress-dashboard/internal"
+	"gopkg.in/yaml.v3"
+)
+
+const dashboardIconsURL = "https://cdn.jsdelivr.net/gh/walkxcode/dashboard-icons/"
+
+// https://gethomepage.dev/latest/configs/services/
+type homepageService struct {
+	Href        string `yaml:"href"`
+	Description string `yaml:"description"`
+	Icon        string `yaml:"icon"`
+}
+
+func importHomepage(reader io.Reader, opts Options) ([]internal.Ingress, error) {
+	// list of groups, each group is a single-key map to list of services (single-key maps)
+	var groups []map[string][]map[string]homepageService
+	if err := yaml.NewDecoder(reader).Decode(&groups); err != nil {
+		return nil, fmt.Errorf("decode homepage services: %w", err)
+	}
+	var ans []internal.Ingress
+	for _, group := range groups {
+		for groupName, services := range group {
+			for _, service := range services {
+				for name, info := range service {
+					ans = append(ans, newIngress(groupName, name, info.Description, homepageIcon(info.Icon), opts, info.Href))
+				}
+			}
+		}
+	}
+
+	return ans, nil
+}
+
+// homepageIcon converts icon reference to URL. Names (ex: sonarr.png) are resolved
+// to dashboard-icons CDN, Material Design and Simple icons are not supported.
+func homepageIcon(icon string) string {
+	switch {
+	case icon == "", strings.HasPrefix(icon, "mdi-"), strings.HasPrefix(icon, "si-"):
+		return ""
+	case strings.Contains(icon, "://"), strings.HasPrefix(icon, "/"):
+		return icon
+	}
+	ext := path.Ext(icon)
+	switch ext {
+	case ".png", ".svg", ".webp":
+		return dashboardIconsURL + ext[1:] + "/" + icon
+	default:
+		return dashboardIconsURL + "png/" + icon + ".png"
+	}
+}
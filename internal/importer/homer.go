@@ -0,0 +1,42 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/reddec/ingress-dashboard/internal"
+	"gopkg.in/yaml.v3"
+)
+
+// https://github.com/bastienwirtz/homer/blob/main/docs/configuration.md
+type homerConfig struct {
+	Services []struct {
+		Name  string `yaml:"name"`
+		Items []struct {
+			Name     string `yaml:"name"`
+			Logo     string `yaml:"logo"`
+			Subtitle string `yaml:"subtitle"`
+			Tag      string `yaml:"tag"`
+			URL      string `yaml:"url"`
+		} `yaml:"items"`
+	} `yaml:"services"`
+}
+
+func importHomer(reader io.Reader, opts Options) ([]internal.Ingress, error) {
+	var config homerConfig
+	if err := yaml.NewDecoder(reader).Decode(&config); err != nil {
+		return nil, fmt.Errorf("decode homer config: %w", err)
+	}
+	var ans []internal.Ingress
+	for _, group := range config.Services {
+		for _, item := range group.Items {
+			ing := newIngress(group.Name, item.Name, item.Subtitle, item.Logo, opts, item.URL)
+			if item.Tag != "" {
+				ing.Tags = []string{item.Tag}
+			}
+			ans = append(ans, ing)
+		}
+	}
+
+	return ans, nil
+}
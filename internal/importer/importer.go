@@ -0,0 +1,93 @@
+// Package importer converts catalogs of other start pages (Homer, Homepage, Heimdall) to static definitions.
+package importer
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/reddec/ingress-dashboard/internal"
+)
+
+type Format string
+
+const (
+	Homer    Format = "homer"    // Homer config.yml
+	Homepage Format = "homepage" // Homepage services.yaml
+	Heimdall Format = "heimdall" // Heimdall JSON export
+)
+
+// Formats supported by Import.
+//nolint:gochecknoglobals
+var Formats = []Format{Homer, Homepage, Heimdall}
+
+type Options struct {
+	// BaseURL of original dashboard used to resolve relative icons. Relative icons are skipped if not set.
+	BaseURL string
+	// Namespace for entries without group.
+	Namespace string
+}
+
+// Import catalog in specified format as static definitions.
+func Import(format Format, reader io.Reader, opts Options) ([]internal.Ingress, error) {
+	switch format {
+	case Homer:
+		return importHomer(reader, opts)
+	case Homepage:
+		return importHomepage(reader, opts)
+	case Heimdall:
+		return importHeimdall(reader, opts)
+	default:
+		return nil, fmt.Errorf("unknown format %s", format) //nolint:goerr113
+	}
+}
+
+func newIngress(namespace, name, description, logo string, opts Options, urls ...string) internal.Ingress {
+	if namespace == "" {
+		namespace = opts.Namespace
+	}
+	ing := internal.Ingress{
+		ID:          namespace + "." + name,
+		Name:        name,
+		Namespace:   namespace,
+		Description: strings.TrimSpace(description),
+		LogoURL:     opts.resolve(logo),
+		Static:      true,
+	}
+	for _, u := range urls {
+		if u == "" {
+			continue
+		}
+		ing.Refs = append(ing.Refs, internal.Ref{
+			URL:    u,
+			Static: true,
+		})
+		ing.TLS = ing.TLS || strings.HasPrefix(u, "https://")
+	}
+
+	return ing
+}
+
+// resolve icon URL: absolute URLs used as-is, relative resolved by base URL or skipped.
+func (opts Options) resolve(icon string) string {
+	if icon == "" {
+		return ""
+	}
+	parsed, err := url.Parse(icon)
+	if err != nil {
+		return ""
+	}
+	if parsed.IsAbs() {
+		return icon
+	}
+	if opts.BaseURL == "" {
+		return ""
+	}
+	base, err := url.Parse(strings.TrimRight(opts.BaseURL, "/") + "/")
+	if err != nil {
+		return ""
+	}
+
+	return base.ResolveReference(&url.URL{Path: strings.TrimLeft(parsed.Path, "/")}).String()
+}
@@ -0,0 +1,83 @@
+package importer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/reddec/ingress-dashboard/internal/importer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImport_homer(t *testing.T) {
+	list, err := importer.Import(importer.Homer, strings.NewReader(`
+services:
+  - name: Applications
+    icon: "fas fa-cloud"
+    items:
+      - name: Awesome app
+        logo: assets/tools/sample.png
+        subtitle: Bookmark example
+        tag: app
+        url: https://www.reddit.com/r/selfhosted/
+      - name: Another app
+        logo: https://example.com/logo.png
+        url: http://example.com
+`), importer.Options{BaseURL: "https://homer.example.com/"})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+
+	require.Equal(t, "Awesome app", list[0].Name)
+	require.Equal(t, "Applications", list[0].Namespace)
+	require.Equal(t, "Bookmark example", list[0].Description)
+	require.Equal(t, "https://homer.example.com/assets/tools/sample.png", list[0].LogoURL)
+	require.Equal(t, []string{"app"}, list[0].Tags)
+	require.Equal(t, "https://www.reddit.com/r/selfhosted/", list[0].Refs[0].URL)
+	require.True(t, list[0].TLS)
+
+	require.Equal(t, "https://example.com/logo.png", list[1].LogoURL)
+	require.False(t, list[1].TLS)
+}
+
+func TestImport_homepage(t *testing.T) {
+	list, err := importer.Import(importer.Homepage, strings.NewReader(`
+- Media:
+    - Sonarr:
+        href: https://sonarr.example.com
+        description: Series management
+        icon: sonarr.png
+    - Plex:
+        href: https://plex.example.com
+        icon: mdi-plex
+- Infra:
+    - Grafana:
+        href: https://grafana.example.com
+        icon: https://grafana.example.com/logo.svg
+`), importer.Options{})
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+
+	require.Equal(t, "Sonarr", list[0].Name)
+	require.Equal(t, "Media", list[0].Namespace)
+	require.Equal(t, "Series management", list[0].Description)
+	require.Equal(t, "https://cdn.jsdelivr.net/gh/walkxcode/dashboard-icons/png/sonarr.png", list[0].LogoURL)
+	require.Empty(t, list[1].LogoURL)
+	require.Equal(t, "Infra", list[2].Namespace)
+	require.Equal(t, "https://grafana.example.com/logo.svg", list[2].LogoURL)
+}
+
+func TestImport_heimdall(t *testing.T) {
+	list, err := importer.Import(importer.Heimdall, strings.NewReader(`[
+  {"title": "Portainer", "url": "https://portainer.example.com", "appdescription": "Container management", "icon": "icons/portainer.png", "type": 0},
+  {"title": "Home", "url": "home", "type": 1},
+  {"title": "Custom", "url": "http://custom.example.com", "description": "{\"enabled\": true}"}
+]`), importer.Options{BaseURL: "https://heimdall.example.com", Namespace: "apps"})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+
+	require.Equal(t, "Portainer", list[0].Name)
+	require.Equal(t, "apps", list[0].Namespace)
+	require.Equal(t, "Container management", list[0].Description)
+	require.Equal(t, "https://heimdall.example.com/storage/icons/portainer.png", list[0].LogoURL)
+	require.Equal(t, "Custom", list[1].Name)
+	require.Empty(t, list[1].Description)
+}
@@ -4,9 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"net/url"
-	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -14,15 +13,19 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
 )
 
 const (
 	AnnoDescription = "ingress-dashboard/description"
 	AnnoLogoURL     = "ingress-dashboard/logo-url"
 	AnnoTitle       = "ingress-dashboard/title"
-	AnnoHide        = "ingress-dashboard/hide"       // do not display ingress in dashboard
-	AnnoURL         = "ingress-dashboard/url"        // custom ingress URL (could be used with load-balancers or reverse-proxies)
-	AnnoAssumeTLS   = "ingress-dashboard/assume-tls" // force protocol as HTTPS (for SSL termination on load-balancers)
+	AnnoHide        = "ingress-dashboard/hide"         // do not display ingress in dashboard
+	AnnoURL         = "ingress-dashboard/url"          // custom ingress URL (could be used with load-balancers or reverse-proxies)
+	AnnoAssumeTLS   = "ingress-dashboard/assume-tls"   // force protocol as HTTPS (for SSL termination on load-balancers)
+	AnnoTags        = "ingress-dashboard/tags"         // comma-separated list of tags
+	AnnoAlias       = "ingress-dashboard/alias"        // short name for /go/ links
+	AnnoPublicBadge = "ingress-dashboard/public-badge" // status badges are available without authorization
 	syncInterval    = 30 * time.Second
 	tlsInterval     = time.Hour
 )
@@ -31,13 +34,23 @@ type Receiver interface {
 	Set(ingresses []Ingress)
 }
 
+// ReceiverFunc adapts function to Receiver interface.
+type ReceiverFunc func(ingresses []Ingress)
+
+func (rf ReceiverFunc) Set(ingresses []Ingress) {
+	rf(ingresses)
+}
+
+// WatchKubernetes watches Ingress objects cluster-wide and pushes them to receiver. Sync status and watch errors
+// are reported to health (optional). Blocks till context canceled.
 func WatchKubernetes(global context.Context, clientset kubernetes.Interface, receiver interface {
 	Set(ingresses []Ingress)
-}) {
+}, health *Health) {
 	ctx, cancel := context.WithCancel(global)
 	defer cancel()
 
 	watcher := newWatcher(ctx, receiver, clientset)
+	watcher.health = health
 
 	var wg sync.WaitGroup
 
@@ -64,64 +77,58 @@ func WatchKubernetes(global context.Context, clientset kubernetes.Interface, rec
 	wg.Wait()
 }
 
+// ListKubernetes returns current Ingress objects from all namespaces. Logo and TLS info are not discovered.
+func ListKubernetes(ctx context.Context, clientset kubernetes.Interface) ([]Ingress, error) {
+	list, err := clientset.NetworkingV1().Ingresses("").List(ctx, v1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list ingresses: %w", err)
+	}
+	watcher := newWatcher(ctx, ReceiverFunc(func([]Ingress) {}), clientset)
+	for i := range list.Items {
+		watcher.upsert(watcher.inspectIngress(ctx, &list.Items[i]))
+	}
+
+	return watcher.items(), nil
+}
+
 func newWatcher(global context.Context, receiver Receiver, clientset kubernetes.Interface) *kubeWatcher {
 	return &kubeWatcher{
-		global:     global,
-		cache:      make(map[string]Ingress),
-		receiver:   receiver,
-		checkLogos: make(chan struct{}, 1),
-		checkCerts: make(chan struct{}, 1),
-		clientset:  clientset,
+		enricher:  newEnricher(receiver),
+		global:    global,
+		clientset: clientset,
+		handled:   newHandledKeys(),
 	}
 }
 
 type kubeWatcher struct {
-	global     context.Context
-	clientset  kubernetes.Interface
-	cache      map[string]Ingress
-	lock       sync.RWMutex
-	receiver   Receiver
-	checkLogos chan struct{}
-	checkCerts chan struct{}
+	*enricher
+	global    context.Context
+	clientset kubernetes.Interface
+	health    *Health
+	handled   *handledKeys
 }
 
 func (kw *kubeWatcher) OnAdd(obj interface{}) {
 	kw.upsertIngress(kw.global, obj)
+	kw.handled.add(obj)
 }
 
 func (kw *kubeWatcher) OnUpdate(_, newObj interface{}) {
 	kw.upsertIngress(kw.global, newObj)
+	kw.handled.add(newObj)
 }
 
 func (kw *kubeWatcher) OnDelete(obj interface{}) {
 	defer kw.notify()
-
-	kw.lock.Lock()
-	defer kw.lock.Unlock()
-	ing, ok := obj.(*v12.IngressClass)
+	kw.handled.remove(obj)
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	ing, ok := obj.(*v12.Ingress)
 	if !ok {
 		return
 	}
-	delete(kw.cache, string(ing.UID))
-}
-
-func (kw *kubeWatcher) runLogoFetcher(ctx context.Context) {
-	for {
-		for _, ing := range kw.items() {
-			if !ing.Hide && ing.LogoURL == "" && len(ing.Refs) > 0 {
-				ing.LogoURL = detectIconURL(ctx, ing.Refs[0].URL)
-				if ing.LogoURL != "" {
-					kw.updateLogo(ing)
-				}
-			}
-		}
-		kw.receiver.Set(kw.items())
-		select {
-		case <-ctx.Done():
-			return
-		case <-kw.checkLogos:
-		}
-	}
+	kw.remove(string(ing.UID))
 }
 
 func (kw *kubeWatcher) runWatcher(ctx context.Context, clientset kubernetes.Interface) {
@@ -129,6 +136,7 @@ func (kw *kubeWatcher) runWatcher(ctx context.Context, clientset kubernetes.Inte
 	informer := informerFactory.Networking().V1().Ingresses().Informer()
 
 	informer.AddEventHandler(kw)
+	trackSync(ctx, kw.health, SourceIngresses, informer, kw.handled, kw.notify)
 	informer.Run(ctx.Done())
 }
 
@@ -138,99 +146,31 @@ func (kw *kubeWatcher) upsertIngress(ctx context.Context, obj interface{}) {
 	if !ok {
 		return
 	}
-	ingress := kw.inspectIngress(ctx, ing)
-
-	kw.lock.Lock()
-	defer kw.lock.Unlock()
-
-	if oldIngress, exists := kw.cache[ingress.UID]; exists {
-		// preserve discovered logo
-		oldLogoURL := oldIngress.LogoURL
-		if oldLogoURL != "" && ingress.LogoURL == "" {
-			ingress.LogoURL = oldLogoURL
-		}
-
-		// preserve cert info as initial value
-		if !oldIngress.Cert.Expiration.IsZero() && ingress.Cert.Expiration.IsZero() {
-			ingress.Cert = oldIngress.Cert
-		}
-	}
-
-	kw.cache[ingress.UID] = ingress
-}
-
-func (kw *kubeWatcher) notify() {
-	kw.receiver.Set(kw.items())
-	select {
-	case kw.checkLogos <- struct{}{}:
-	default:
-	}
-
-	select {
-	case kw.checkCerts <- struct{}{}:
-	default:
-	}
-}
-
-func (kw *kubeWatcher) items() []Ingress {
-	kw.lock.RLock()
-	defer kw.lock.RUnlock()
-
-	return toList(kw.cache)
-}
-
-func (kw *kubeWatcher) updateLogo(ingress Ingress) {
-	kw.lock.Lock()
-	defer kw.lock.Unlock()
-	old, exists := kw.cache[ingress.UID]
-	if !exists || old.LogoURL != "" {
-		return
-	}
-	old.LogoURL = ingress.LogoURL
-	kw.cache[ingress.UID] = old
-}
-
-func (kw *kubeWatcher) updateCertInfo(ingress Ingress) {
-	kw.lock.Lock()
-	defer kw.lock.Unlock()
-	old, exists := kw.cache[ingress.UID]
-	if !exists {
-		return
-	}
-	old.Cert = ingress.Cert
-	kw.cache[ingress.UID] = old
+	kw.upsert(kw.inspectIngress(ctx, ing))
 }
 
 func (kw *kubeWatcher) inspectIngress(ctx context.Context, ing *v12.Ingress) Ingress {
 	forceTLS := toBool(ing.Annotations[AnnoAssumeTLS], false)
 
 	return Ingress{
-		Class:       getClassName(ing),
-		Name:        ing.Name,
-		Namespace:   ing.Namespace,
-		Title:       ing.Annotations[AnnoTitle],
-		ID:          ing.Namespace + "." + ing.Name,
-		UID:         string(ing.UID),
-		Description: ing.Annotations[AnnoDescription],
-		LogoURL:     ing.Annotations[AnnoLogoURL],
-		Hide:        toBool(ing.Annotations[AnnoHide], false),
-		Refs:        kw.getRefs(ctx, ing, forceTLS),
-		TLS:         forceTLS || len(ing.Spec.TLS) > 0,
+		Class:        getClassName(ing),
+		Name:         ing.Name,
+		Namespace:    ing.Namespace,
+		Title:        ing.Annotations[AnnoTitle],
+		ID:           ing.Namespace + "." + ing.Name,
+		UID:          string(ing.UID),
+		Description:  ing.Annotations[AnnoDescription],
+		Descriptions: toDescriptions(ing.Annotations),
+		LogoURL:      ing.Annotations[AnnoLogoURL],
+		Hide:         toBool(ing.Annotations[AnnoHide], false),
+		Tags:         toTags(ing.Annotations[AnnoTags]),
+		Alias:        strings.TrimSpace(ing.Annotations[AnnoAlias]),
+		PublicBadge:  toBool(ing.Annotations[AnnoPublicBadge], false),
+		Refs:         kw.getRefs(ctx, ing, forceTLS),
+		TLS:          forceTLS || len(ing.Spec.TLS) > 0,
 	}
 }
 
-func toList(cache map[string]Ingress) []Ingress {
-	var cp = make([]Ingress, 0, len(cache))
-	for _, ing := range cache {
-		cp = append(cp, ing)
-	}
-	sort.Slice(cp, func(i, j int) bool {
-		return cp[i].ID < cp[j].ID
-	})
-
-	return cp
-}
-
 func (kw *kubeWatcher) getRefs(ctx context.Context, ing *v12.Ingress, forceTLS bool) []Ref {
 	if staticURL, ok := ing.Annotations[AnnoURL]; ok {
 		podsNum, err := kw.getTotalPodsNum(ctx, ing)
@@ -307,65 +247,44 @@ func (kw *kubeWatcher) getPodsNum(ctx context.Context, namespace string, svc *v1
 	return len(info.Spec.ClusterIPs) + extHosts, nil
 }
 
-func (kw *kubeWatcher) runCertsInfoCheck(ctx context.Context) {
-	timer := time.NewTicker(tlsInterval)
-	defer timer.Stop()
-
-	for {
-		kw.scanTLSCerts(ctx)
-		select {
-		case <-kw.checkCerts:
-		case <-timer.C:
-		case <-ctx.Done():
-			return
-		}
+func toBool(value string, defaultValue bool) bool {
+	if v, err := strconv.ParseBool(value); err == nil {
+		return v
 	}
-}
-
-func (kw *kubeWatcher) scanTLSCerts(ctx context.Context) {
-	for _, item := range kw.items() {
-		if !item.TLS {
-			continue
-		}
-
-		info := fetchCertInfo(ctx, item)
-		if info == nil {
-			log.Println("no cert info for", item.ID)
 
-			continue
-		}
-
-		item.Cert = *info
-		kw.updateCertInfo(item)
-		kw.receiver.Set(kw.items())
-	}
-	kw.receiver.Set(kw.items())
+	return defaultValue
 }
 
-func fetchCertInfo(ctx context.Context, item Ingress) *CertInfo {
-	for _, u := range item.Refs {
-		if parsedURL, err := url.Parse(u.URL); err == nil {
-			host := parsedURL.Hostname()
-			crtInfo, err := Expiration(ctx, host)
-			if err != nil {
-				log.Println("failed get expiration time", host, ":", err)
-
-				continue
-			}
-
-			return &crtInfo // stop on first ref
+func toTags(value string) []string {
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			tags = append(tags, tag)
 		}
 	}
 
-	return nil
+	return tags
 }
 
-func toBool(value string, defaultValue bool) bool {
-	if v, err := strconv.ParseBool(value); err == nil {
-		return v
+// toDescriptions from annotations ingress-dashboard/description.<lang>.
+func toDescriptions(annotations map[string]string) map[string]string {
+	var ans map[string]string
+	for key, value := range annotations {
+		if !strings.HasPrefix(key, AnnoDescription+".") {
+			continue
+		}
+		lang := strings.ToLower(strings.TrimPrefix(key, AnnoDescription+"."))
+		if lang == "" || value == "" {
+			continue
+		}
+		if ans == nil {
+			ans = make(map[string]string)
+		}
+		ans[lang] = value
 	}
 
-	return defaultValue
+	return ans
 }
 
 func getClassName(ing *v12.Ingress) string {
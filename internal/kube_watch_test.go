@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	v12 "k8s.io/api/networking/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestKubeWatcher_OnDelete(t *testing.T) {
+	newIngress := func(name string) *v12.Ingress {
+		return &v12.Ingress{ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name)}}
+	}
+	first, second, third := newIngress("first"), newIngress("second"), newIngress("third")
+
+	var received []Ingress
+	watcher := newWatcher(context.Background(), ReceiverFunc(func(ingresses []Ingress) {
+		received = ingresses
+	}), fake.NewSimpleClientset())
+	watcher.OnAdd(first)
+	watcher.OnAdd(second)
+	watcher.OnAdd(third)
+	require.Len(t, received, 3)
+
+	watcher.OnDelete(first)
+	require.Len(t, received, 2)
+
+	// delete event was missed, informer passes last known state
+	watcher.OnDelete(cache.DeletedFinalStateUnknown{Key: "default/second", Obj: second})
+	require.Len(t, received, 1)
+	require.Equal(t, "third", received[0].Name)
+
+	// unknown objects are ignored
+	watcher.OnDelete(&v12.IngressClass{ObjectMeta: v1.ObjectMeta{Name: "third", UID: "third"}})
+	require.Len(t, received, 1)
+}
+
+func TestTrackSync_delayedHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	clientset := fake.NewSimpleClientset(
+		&v12.Ingress{ObjectMeta: v1.ObjectMeta{Name: "first", Namespace: "default", UID: "first"}},
+		&v12.Ingress{ObjectMeta: v1.ObjectMeta{Name: "second", Namespace: "default", UID: "second"}},
+	)
+	var lock sync.Mutex
+	var received []Ingress
+	watcher := newWatcher(ctx, ReceiverFunc(func(ingresses []Ingress) {
+		lock.Lock()
+		defer lock.Unlock()
+		received = ingresses
+	}), clientset)
+	health := NewHealth()
+	health.Register(SourceIngresses)
+
+	// handlers are called asynchronously and could be slow (lookup of services and pods)
+	release := make(chan struct{})
+	informer := informers.NewSharedInformerFactory(clientset, 0).Networking().V1().Ingresses().Informer()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{AddFunc: func(obj interface{}) {
+		<-release
+		watcher.OnAdd(obj)
+	}})
+	synced := make(chan struct{})
+	trackSync(ctx, health, SourceIngresses, informer, watcher.handled, func() {
+		watcher.notify()
+		close(synced)
+	})
+	go informer.Run(ctx.Done())
+
+	require.Eventually(t, informer.HasSynced, time.Second, 10*time.Millisecond)
+	time.Sleep(3 * handledPoll)
+	require.True(t, health.Loading(), "informer cache is synced, but objects are not handled")
+
+	close(release)
+	select {
+	case <-synced:
+	case <-time.After(5 * time.Second):
+		t.Fatal("source not synced")
+	}
+	require.False(t, health.Loading())
+	lock.Lock()
+	defer lock.Unlock()
+	require.Len(t, received, 2, "catalog should be complete after sync")
+}
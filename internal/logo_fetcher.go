@@ -17,19 +17,21 @@ var (
 	errNoLogo = errors.New("no logo in meta")
 )
 
-func detectIconURL(ctx context.Context, url string) string {
+// detectIconURL from main page or favicon.ico. Error describes why both ways failed.
+func detectIconURL(ctx context.Context, url string) (string, error) {
 	u, err := mainSrcPageIcon(ctx, url)
 	if err == nil {
-		return u
+		return u, nil
 	}
 	log.Println("detect icon from main page", url, ":", err)
 	// fallback to classical icon
 	faviconURL := url + "/favicon.ico"
-	if pingURL(ctx, faviconURL) == nil {
-		return faviconURL
+	pingErr := pingURL(ctx, faviconURL)
+	if pingErr == nil {
+		return faviconURL, nil
 	}
 
-	return ""
+	return "", fmt.Errorf("main page: %v, favicon.ico: %w", err, pingErr) //nolint:errorlint
 }
 
 func mainSrcPageIcon(ctx context.Context, pageURL string) (string, error) {
@@ -0,0 +1,243 @@
+package internal
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+)
+
+const metricsNamespace = "ingress_dashboard"
+
+const (
+	scanLogo = "logo"
+	scanTLS  = "tls"
+)
+
+const handledPoll = 100 * time.Millisecond // how often handled objects are compared with informer cache
+
+// internal metrics registered in default registry.
+//
+//nolint:gochecknoglobals
+var (
+	informerSynced = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "informer_synced",
+		Help:      "1 if informer cache synced with Kubernetes API",
+	}, []string{"resource"})
+	scanDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "scan_duration_seconds",
+		Help:      "Duration of logo and TLS scans over all entries",
+		Buckets:   []float64{.1, .5, 1, 5, 10, 30, 60, 120, 300},
+	}, []string{"scan"})
+	scanErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "scan_errors_total",
+		Help:      "Number of failed logo and TLS info discoveries, repeated failures of the same entry are counted once",
+	}, []string{"scan"})
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"handler", "method", "code"})
+)
+
+// per-entry metrics descriptions.
+//
+//nolint:gochecknoglobals
+var (
+	certExpiryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "cert_expiry_timestamp_seconds"),
+		"Expiration time of TLS certificate as unix timestamp",
+		[]string{"namespace", "name", "host", "cert_host"}, nil)
+	tlsEnabledDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "tls_enabled"),
+		"1 if TLS enabled for entry",
+		[]string{"namespace", "name", "host"}, nil)
+	readyBackendsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "ref_ready_backends"),
+		"Number of ready hosts behind reference. Static references are not reported",
+		[]string{"namespace", "name", "host", "url"}, nil)
+	hiddenDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "entry_hidden"),
+		"1 if entry hidden in UI",
+		[]string{"namespace", "name", "host"}, nil)
+	staticDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "entry_static"),
+		"1 if entry defined by static source or dashboard entry",
+		[]string{"namespace", "name", "host"}, nil)
+)
+
+// Describe per-entry metrics. Implements prometheus.Collector.
+func (svc *Service) Describe(descs chan<- *prometheus.Desc) {
+	descs <- certExpiryDesc
+	descs <- tlsEnabledDesc
+	descs <- readyBackendsDesc
+	descs <- hiddenDesc
+	descs <- staticDesc
+}
+
+// Collect per-entry metrics of all (including hidden) entries. Implements prometheus.Collector.
+func (svc *Service) Collect(metrics chan<- prometheus.Metric) {
+	// the same namespace and name could be used by different sources, only first entry is reported
+	var seen = make(map[string]bool)
+	gauge := func(desc *prometheus.Desc, value float64, labels ...string) {
+		key := desc.String()
+		for _, label := range labels {
+			key += "\x00" + label
+		}
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		metrics <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value, labels...)
+	}
+
+	for _, ing := range svc.getList() {
+		host := entryHost(ing)
+		gauge(tlsEnabledDesc, boolValue(ing.TLS), ing.Namespace, ing.Name, host)
+		gauge(hiddenDesc, boolValue(ing.Hide), ing.Namespace, ing.Name, host)
+		gauge(staticDesc, boolValue(ing.Static), ing.Namespace, ing.Name, host)
+		if !ing.Cert.Expiration.IsZero() {
+			gauge(certExpiryDesc, float64(ing.Cert.Expiration.Unix()), ing.Namespace, ing.Name, host, ing.Cert.Host)
+		}
+		for _, ref := range ing.Refs {
+			if ref.Static {
+				continue
+			}
+			gauge(readyBackendsDesc, float64(ref.Pods), ing.Namespace, ing.Name, host, ref.URL)
+		}
+	}
+}
+
+// entryHost is hostname of the first link, empty if entry has no links.
+func entryHost(ingress Ingress) string {
+	if len(ingress.Refs) == 0 {
+		return ""
+	}
+
+	return hostname(ingress.Refs[0].URL)
+}
+
+// MetricsHandler exposes metrics from default registry.
+func MetricsHandler() http.Handler {
+	return promhttp.Handler()
+}
+
+// instrument handler by request latency metric.
+func instrument(route string, handle httprouter.Handle) httprouter.Handle {
+	observer := requestDuration.MustCurryWith(prometheus.Labels{"handler": route})
+
+	return func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		promhttp.InstrumentHandlerDuration(observer, http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			handle(writer, request, params)
+		})).ServeHTTP(writer, request)
+	}
+}
+
+// trackSync reports watch errors and marks source as synced (in health and metrics) once informer cache synced
+// and all cached objects are processed by event handler. Informer calls handlers asynchronously, so its own sync
+// status means that catalog could be still partial. After sync onSynced is called. Should be called before
+// informer started.
+func trackSync(ctx context.Context, health *Health, resource string, informer cache.SharedInformer, handled *handledKeys, onSynced func()) {
+	if err := informer.SetWatchErrorHandler(func(r *cache.Reflector, err error) {
+		health.Failed(resource, err)
+		cache.DefaultWatchErrorHandler(r, err)
+	}); err != nil {
+		log.Println("failed set watch error handler for", resource, ":", err)
+	}
+	informerSynced.WithLabelValues(resource).Set(0)
+	go func() {
+		if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+			return
+		}
+		err := wait.PollImmediateUntil(handledPoll, func() (bool, error) {
+			return handled.covers(informer.GetStore().ListKeys()), nil
+		}, ctx.Done())
+		if err != nil {
+			return
+		}
+		health.Synced(resource)
+		informerSynced.WithLabelValues(resource).Set(1)
+		onSynced()
+	}()
+}
+
+func newHandledKeys() *handledKeys {
+	return &handledKeys{keys: make(map[string]bool)}
+}
+
+// handledKeys are keys (namespace/name) of objects processed by event handler. Nil handledKeys ignores all updates
+// and covers everything.
+type handledKeys struct {
+	lock sync.Mutex
+	keys map[string]bool
+}
+
+func (hk *handledKeys) add(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if hk == nil || err != nil {
+		return
+	}
+	hk.lock.Lock()
+	defer hk.lock.Unlock()
+	hk.keys[key] = true
+}
+
+func (hk *handledKeys) remove(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if hk == nil || err != nil {
+		return
+	}
+	hk.lock.Lock()
+	defer hk.lock.Unlock()
+	delete(hk.keys, key)
+}
+
+// covers returns true if all keys are handled.
+func (hk *handledKeys) covers(keys []string) bool {
+	if hk == nil {
+		return true
+	}
+	hk.lock.Lock()
+	defer hk.lock.Unlock()
+	for _, key := range keys {
+		if !hk.keys[key] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func observeScan(scan string, started time.Time) {
+	scanDuration.WithLabelValues(scan).Observe(time.Since(started).Seconds())
+}
+
+func hostname(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	return u.Hostname()
+}
+
+func boolValue(value bool) float64 {
+	if value {
+		return 1
+	}
+
+	return 0
+}
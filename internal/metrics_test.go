@@ -0,0 +1,80 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Collect(t *testing.T) {
+	expiration := time.Date(2022, 1, 30, 10, 0, 0, 0, time.UTC)
+	svc := New()
+	svc.Prepend([]Ingress{{
+		Name:      "docs",
+		Namespace: "external",
+		Static:    true,
+		Refs:      []Ref{{URL: "https://docs.example.com", Static: true}},
+	}})
+	svc.Set([]Ingress{{
+		Name:      "grafana",
+		Namespace: "monitoring",
+		TLS:       true,
+		Cert:      CertInfo{Expiration: expiration, Host: "grafana.example.org"},
+		Refs:      []Ref{{URL: "https://grafana.example.com/", Pods: 2}, {URL: "https://grafana.internal/admin", Pods: 1}},
+	}, {
+		Name:      "secret",
+		Namespace: "monitoring",
+		Hide:      true,
+		Refs:      []Ref{{URL: "http://secret.example.com/"}},
+	}})
+
+	err := testutil.CollectAndCompare(svc, strings.NewReader(`
+# HELP ingress_dashboard_cert_expiry_timestamp_seconds Expiration time of TLS certificate as unix timestamp
+# TYPE ingress_dashboard_cert_expiry_timestamp_seconds gauge
+ingress_dashboard_cert_expiry_timestamp_seconds{cert_host="grafana.example.org",host="grafana.example.com",name="grafana",namespace="monitoring"} 1.6435368e+09
+# HELP ingress_dashboard_entry_hidden 1 if entry hidden in UI
+# TYPE ingress_dashboard_entry_hidden gauge
+ingress_dashboard_entry_hidden{host="docs.example.com",name="docs",namespace="external"} 0
+ingress_dashboard_entry_hidden{host="grafana.example.com",name="grafana",namespace="monitoring"} 0
+ingress_dashboard_entry_hidden{host="secret.example.com",name="secret",namespace="monitoring"} 1
+# HELP ingress_dashboard_entry_static 1 if entry defined by static source or dashboard entry
+# TYPE ingress_dashboard_entry_static gauge
+ingress_dashboard_entry_static{host="docs.example.com",name="docs",namespace="external"} 1
+ingress_dashboard_entry_static{host="grafana.example.com",name="grafana",namespace="monitoring"} 0
+ingress_dashboard_entry_static{host="secret.example.com",name="secret",namespace="monitoring"} 0
+# HELP ingress_dashboard_ref_ready_backends Number of ready hosts behind reference. Static references are not reported
+# TYPE ingress_dashboard_ref_ready_backends gauge
+ingress_dashboard_ref_ready_backends{host="grafana.example.com",name="grafana",namespace="monitoring",url="https://grafana.example.com/"} 2
+ingress_dashboard_ref_ready_backends{host="grafana.example.com",name="grafana",namespace="monitoring",url="https://grafana.internal/admin"} 1
+ingress_dashboard_ref_ready_backends{host="secret.example.com",name="secret",namespace="monitoring",url="http://secret.example.com/"} 0
+# HELP ingress_dashboard_tls_enabled 1 if TLS enabled for entry
+# TYPE ingress_dashboard_tls_enabled gauge
+ingress_dashboard_tls_enabled{host="docs.example.com",name="docs",namespace="external"} 0
+ingress_dashboard_tls_enabled{host="grafana.example.com",name="grafana",namespace="monitoring"} 1
+ingress_dashboard_tls_enabled{host="secret.example.com",name="secret",namespace="monitoring"} 0
+`))
+	require.NoError(t, err)
+}
+
+func TestEnricher_scanErrors(t *testing.T) {
+	en := newEnricher(ReceiverFunc(func([]Ingress) {}))
+	en.upsert(Ingress{UID: "app", TLS: true, Refs: []Ref{{URL: "https://app.example.com"}}})
+	logoErrors := testutil.ToFloat64(scanErrors.WithLabelValues(scanLogo))
+	certErrors := testutil.ToFloat64(scanErrors.WithLabelValues(scanTLS))
+
+	// discovery is retried on every change, but failures of the same entry are counted once
+	en.updateLogoError("app", errNoRefs)
+	en.updateLogoError("app", errNoRefs)
+	en.updateCertError("app", errNoRefs)
+	en.updateCertError("app", errNoRefs)
+	require.Equal(t, logoErrors+1, testutil.ToFloat64(scanErrors.WithLabelValues(scanLogo)))
+	require.Equal(t, certErrors+1, testutil.ToFloat64(scanErrors.WithLabelValues(scanTLS)))
+
+	// failure after success is counted again
+	en.updateCertInfo(Ingress{UID: "app"})
+	en.updateCertError("app", errNoRefs)
+	require.Equal(t, certErrors+2, testutil.ToFloat64(scanErrors.WithLabelValues(scanTLS)))
+}
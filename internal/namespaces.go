@@ -0,0 +1,251 @@
+package internal
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/reddec/ingress-dashboard/internal/auth"
+	v13 "k8s.io/api/core/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+const namespacesDebounce = time.Second // delay before publishing changed namespaces
+
+// Namespace metadata. Title and description are defined by the same annotations as for Ingress.
+type Namespace struct {
+	Name         string
+	Title        string
+	Description  string
+	Descriptions map[string]string // descriptions in other languages by language code
+	Labels       map[string]string
+	Created      time.Time
+}
+
+// Label is title or name.
+func (ns Namespace) Label() string {
+	if ns.Title != "" {
+		return ns.Title
+	}
+
+	return ns.Name
+}
+
+// NamespaceHealth is aggregated state of entries in namespace.
+type NamespaceHealth struct {
+	Total        int // number of entries
+	Healthy      int // entries without problems
+	Expired      int // entries with expired certificates
+	SoonExpire   int // entries with certificates which expire soon
+	DeadRefs     int // entries with links not served by any host
+	MissingClass int // ingresses without class (served by default ingress controller)
+}
+
+// Problems is number of entries with at least one problem.
+func (nh NamespaceHealth) Problems() int {
+	return nh.Total - nh.Healthy
+}
+
+func namespaceHealth(list []Ingress) NamespaceHealth {
+	var ans = NamespaceHealth{Total: len(list)}
+	for _, ing := range list {
+		var problem bool
+		switch ing.TLSStatus() {
+		case TLSExpired:
+			ans.Expired++
+			problem = true
+		case TLSSoonExpire:
+			ans.SoonExpire++
+			problem = true
+		}
+		if ing.HasDeadRefs() {
+			ans.DeadRefs++
+			problem = true
+		}
+		if !ing.Static && ing.Class == "" {
+			ans.MissingClass++
+			problem = true
+		}
+		if !problem {
+			ans.Healthy++
+		}
+	}
+
+	return ans
+}
+
+type UINamespaceContext struct {
+	UIContext
+	Namespace Namespace
+	Health    NamespaceHealth
+}
+
+// SetNamespaces replaces metadata of namespaces.
+func (svc *Service) SetNamespaces(list []Namespace) {
+	var byName = make(map[string]Namespace, len(list))
+	for _, ns := range list {
+		byName[ns.Name] = ns
+	}
+	svc.namespaces.Store(byName)
+}
+
+func (svc *Service) getNamespace(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	name := params.ByName("ns")
+	locale := svc.locale(writer, request)
+	list := locale.localize(visibleIngresses(svc.getList()))
+	var entries []Ingress
+	for _, ing := range list {
+		if ing.Namespace == name {
+			entries = append(entries, ing)
+		}
+	}
+	known, _ := svc.namespaces.Load().(map[string]Namespace)
+	if len(entries) == 0 {
+		// metadata alone is not shown: namespaces without (visible) entries are not part of dashboard
+		http.NotFound(writer, request)
+
+		return
+	}
+	ns := known[name]
+	ns.Name = name
+	if description, ok := ns.Descriptions[locale.Lang]; ok {
+		ns.Description = description
+	}
+
+	writer.Header().Set("Content-Type", "text/html")
+	if err := svc.namespace.Execute(writer, UINamespaceContext{
+		UIContext: UIContext{
+			Ingresses: entries,
+			User:      auth.UserFromContext(request.Context()),
+			Loading:   svc.health.Loading(),
+			Problems:  countProblems(list),
+			Branding:  svc.branding,
+			Locale:    locale,
+			Locales:   svc.locales.list,
+		},
+		Namespace: ns,
+		Health:    namespaceHealth(entries),
+	}); err != nil {
+		log.Println("failed render namespace page:", err)
+	}
+}
+
+// WatchNamespaces watches metadata of namespaces and pushes them to receiver. Metadata is optional: errors
+// (for example, missing permissions) are only logged and do not affect readiness. Blocks till context canceled.
+func WatchNamespaces(ctx context.Context, clientset kubernetes.Interface, receiver interface {
+	SetNamespaces(list []Namespace)
+}) {
+	watcher := newNamespaceWatcher(receiver)
+	informerFactory := informers.NewSharedInformerFactory(clientset, syncInterval)
+	informer := informerFactory.Core().V1().Namespaces().Informer()
+
+	informer.AddEventHandler(watcher)
+	go watcher.run(ctx)
+	informer.Run(ctx.Done())
+}
+
+func newNamespaceWatcher(receiver interface {
+	SetNamespaces(list []Namespace)
+}) *namespaceWatcher {
+	return &namespaceWatcher{
+		receiver:   receiver,
+		namespaces: make(map[string]Namespace),
+		changed:    make(chan struct{}, 1),
+	}
+}
+
+type namespaceWatcher struct {
+	receiver interface {
+		SetNamespaces(list []Namespace)
+	}
+	lock       sync.Mutex
+	namespaces map[string]Namespace
+	changed    chan struct{}
+}
+
+// run publishes namespaces after changes. Changes are debounced: initial sync produces event per namespace.
+func (nw *namespaceWatcher) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-nw.changed:
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(namespacesDebounce):
+		}
+		nw.publish()
+	}
+}
+
+func (nw *namespaceWatcher) OnAdd(obj interface{}) {
+	nw.upsert(obj)
+}
+
+func (nw *namespaceWatcher) OnUpdate(_, newObj interface{}) {
+	nw.upsert(newObj)
+}
+
+func (nw *namespaceWatcher) OnDelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	ns, ok := obj.(*v13.Namespace)
+	if !ok {
+		return
+	}
+	nw.lock.Lock()
+	delete(nw.namespaces, ns.Name)
+	nw.lock.Unlock()
+	nw.notify()
+}
+
+func (nw *namespaceWatcher) upsert(obj interface{}) {
+	ns, ok := obj.(*v13.Namespace)
+	if !ok {
+		return
+	}
+	nw.lock.Lock()
+	nw.namespaces[ns.Name] = toNamespace(ns)
+	nw.lock.Unlock()
+	nw.notify()
+}
+
+func (nw *namespaceWatcher) notify() {
+	select {
+	case nw.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (nw *namespaceWatcher) publish() {
+	nw.lock.Lock()
+	var list = make([]Namespace, 0, len(nw.namespaces))
+	for _, ns := range nw.namespaces {
+		list = append(list, ns)
+	}
+	nw.lock.Unlock()
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	nw.receiver.SetNamespaces(list)
+}
+
+func toNamespace(ns *v13.Namespace) Namespace {
+	return Namespace{
+		Name:         ns.Name,
+		Title:        ns.Annotations[AnnoTitle],
+		Description:  ns.Annotations[AnnoDescription],
+		Descriptions: toDescriptions(ns.Annotations),
+		Labels:       ns.Labels,
+		Created:      ns.CreationTimestamp.Time,
+	}
+}
@@ -0,0 +1,109 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	v13 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNamespaceHealth(t *testing.T) {
+	list := []Ingress{
+		{Name: "healthy", Class: "nginx", TLS: true, Cert: CertInfo{Expiration: time.Now().Add(2 * SoonExpiredInterval)}, Refs: []Ref{{Pods: 1}}},
+		{Name: "expired", Class: "nginx", TLS: true, Cert: CertInfo{Expiration: time.Now().Add(-time.Hour)}, Refs: []Ref{{Pods: 1}}},
+		{Name: "soon", TLS: true, Cert: CertInfo{Expiration: time.Now().Add(time.Hour)}, Refs: []Ref{{}}},
+		{Name: "static", Static: true, Refs: []Ref{{Static: true}}},
+	}
+
+	health := namespaceHealth(list)
+	require.Equal(t, NamespaceHealth{
+		Total:        4,
+		Healthy:      2,
+		Expired:      1,
+		SoonExpire:   1,
+		DeadRefs:     1,
+		MissingClass: 1,
+	}, health)
+	require.Equal(t, 2, health.Problems())
+}
+
+func TestToNamespace(t *testing.T) {
+	ns := toNamespace(&v13.Namespace{ObjectMeta: v1.ObjectMeta{
+		Name:   "monitoring",
+		Labels: map[string]string{"team": "sre"},
+		Annotations: map[string]string{
+			AnnoTitle:               "Monitoring",
+			AnnoDescription:         "Metrics and dashboards",
+			AnnoDescription + ".de": "Metriken und Dashboards",
+		},
+	}})
+	require.Equal(t, "Monitoring", ns.Label())
+	require.Equal(t, "Metrics and dashboards", ns.Description)
+	require.Equal(t, map[string]string{"de": "Metriken und Dashboards"}, ns.Descriptions)
+	require.Equal(t, map[string]string{"team": "sre"}, ns.Labels)
+
+	var received []Namespace
+	watcher := newNamespaceWatcher(namespaceReceiverFunc(func(list []Namespace) { received = list }))
+	watcher.OnAdd(&v13.Namespace{ObjectMeta: v1.ObjectMeta{Name: "b"}})
+	watcher.OnAdd(&v13.Namespace{ObjectMeta: v1.ObjectMeta{Name: "a"}})
+	require.Len(t, watcher.changed, 1, "changes should be coalesced")
+	require.Empty(t, received, "changes should be published in background")
+	<-watcher.changed
+	watcher.publish()
+	require.Len(t, received, 2)
+	require.Equal(t, "a", received[0].Name)
+	watcher.OnDelete(&v13.Namespace{ObjectMeta: v1.ObjectMeta{Name: "a"}})
+	<-watcher.changed
+	watcher.publish()
+	require.Len(t, received, 1)
+	require.Equal(t, "b", received[0].Name)
+}
+
+type namespaceReceiverFunc func(list []Namespace)
+
+func (f namespaceReceiverFunc) SetNamespaces(list []Namespace) {
+	f(list)
+}
+
+func TestService_namespace(t *testing.T) {
+	svc := New()
+	svc.Set([]Ingress{
+		{UID: "grafana-dev", Name: "grafana-dev", Namespace: "dev", Refs: []Ref{{URL: "http://grafana.dev.example.com"}}},
+		{UID: "grafana", Name: "grafana", Namespace: "monitoring", Refs: []Ref{{URL: "https://grafana.example.com"}}},
+		{UID: "prometheus", Name: "prometheus", Namespace: "monitoring", Refs: []Ref{{URL: "http://prometheus.example.com"}}},
+	})
+	svc.SetNamespaces([]Namespace{
+		{Name: "monitoring", Title: "Monitoring", Labels: map[string]string{"team": "sre"}},
+		{Name: "empty"},
+	})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		res := httptest.NewRecorder()
+		svc.ServeHTTP(res, httptest.NewRequest(http.MethodGet, path, nil))
+
+		return res
+	}
+
+	res := get("/namespaces/monitoring")
+	require.Equal(t, http.StatusOK, res.Code)
+	body := res.Body.String()
+	require.Contains(t, body, "<h2 style=\"margin-bottom: 0; margin-top: 0\">Monitoring</h2>")
+	require.Contains(t, body, "<code>team=sre</code>")
+	require.Contains(t, body, "2 entries")
+	require.Contains(t, body, `href="./../details/grafana"`)
+	require.Contains(t, body, `href="./../details/prometheus"`)
+	require.NotContains(t, body, `href="./../details/grafana-dev"`)
+
+	// without metadata
+	res = get("/namespaces/dev")
+	require.Equal(t, http.StatusOK, res.Code)
+	require.Contains(t, res.Body.String(), "1 entry")
+
+	// metadata without entries
+	require.Equal(t, http.StatusNotFound, get("/namespaces/empty").Code)
+	require.Equal(t, http.StatusNotFound, get("/namespaces/unknown").Code)
+}
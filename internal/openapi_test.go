@@ -0,0 +1,188 @@
+package internal_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type apiCase struct {
+	operation string
+	path      string
+	code      int
+}
+
+// each documented operation and response code should be covered by at least one case.
+//nolint:gochecknoglobals
+var apiCases = []apiCase{
+	{operation: "listIngresses", path: "/api/v1/ingresses", code: http.StatusOK},
+	{operation: "listIngresses", path: "/api/v1/ingresses?namespace=default&class=nginx&tag=monitoring&tls=expired&dead=false&q=grafana", code: http.StatusOK},
+	{operation: "listIngresses", path: "/api/v1/ingresses?tls=broken", code: http.StatusBadRequest},
+	{operation: "getIngress", path: "/api/v1/ingresses/uid-grafana", code: http.StatusOK},
+	{operation: "getIngress", path: "/api/v1/ingresses/uid-prometheus", code: http.StatusOK},
+	{operation: "getIngress", path: "/api/v1/ingresses/unknown", code: http.StatusNotFound},
+	{operation: "listNamespaces", path: "/api/v1/namespaces", code: http.StatusOK},
+}
+
+type openAPI struct {
+	Paths map[string]map[string]struct {
+		OperationID string `json:"operationId"`
+		Parameters  []struct {
+			Name string `json:"name"`
+			In   string `json:"in"`
+		} `json:"parameters"`
+		Responses map[string]struct {
+			Content map[string]struct {
+				Schema map[string]interface{} `json:"schema"`
+			} `json:"content"`
+		} `json:"responses"`
+	} `json:"paths"`
+	Components struct {
+		Schemas map[string]map[string]interface{} `json:"schemas"`
+	} `json:"components"`
+}
+
+func TestOpenAPI(t *testing.T) {
+	svc := testService()
+
+	res := httptest.NewRecorder()
+	svc.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+	require.Equal(t, http.StatusOK, res.Code)
+
+	var spec openAPI
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&spec))
+
+	var covered = make(map[string]bool)
+	for _, c := range apiCases {
+		covered[c.operation+" "+http.StatusText(c.code)] = true
+	}
+
+	for path, methods := range spec.Paths {
+		for method, op := range methods {
+			require.Equal(t, "get", method, "only GET methods expected")
+			for code := range op.Responses {
+				var status int
+				require.NoError(t, json.Unmarshal([]byte(code), &status))
+				require.True(t, covered[op.OperationID+" "+http.StatusText(status)], "response %s of %s (%s) not covered by test", code, op.OperationID, path)
+			}
+			for _, param := range op.Parameters {
+				if param.In == "path" {
+					require.Contains(t, path, "{"+param.Name+"}")
+				}
+			}
+		}
+	}
+
+	for _, c := range apiCases {
+		c := c
+		t.Run(c.path, func(t *testing.T) {
+			schema := findResponseSchema(t, spec, c)
+
+			res := httptest.NewRecorder()
+			svc.ServeHTTP(res, httptest.NewRequest(http.MethodGet, c.path, nil))
+			require.Equal(t, c.code, res.Code)
+			require.Equal(t, "application/json", res.Header().Get("Content-Type"))
+
+			var body interface{}
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+			validateSchema(t, spec, schema, body, "$")
+		})
+	}
+}
+
+func findResponseSchema(t *testing.T, spec openAPI, c apiCase) map[string]interface{} {
+	t.Helper()
+	for path, methods := range spec.Paths {
+		op, ok := methods["get"]
+		if !ok || op.OperationID != c.operation {
+			continue
+		}
+		require.True(t, matchPath(path, strings.Split(c.path, "?")[0]), "path %s does not match %s", c.path, path)
+		for code, response := range op.Responses {
+			var status int
+			require.NoError(t, json.Unmarshal([]byte(code), &status))
+			if status == c.code {
+				content, ok := response.Content["application/json"]
+				require.True(t, ok, "no JSON content for %s %s", c.operation, code)
+
+				return content.Schema
+			}
+		}
+		require.Failf(t, "response not documented", "%s %d", c.operation, c.code)
+	}
+	require.Failf(t, "operation not documented", c.operation)
+
+	return nil
+}
+
+func matchPath(template, path string) bool {
+	templateParts := strings.Split(template, "/")
+	pathParts := strings.Split(path, "/")
+	if len(templateParts) != len(pathParts) {
+		return false
+	}
+	for i, part := range templateParts {
+		if strings.HasPrefix(part, "{") {
+			continue
+		}
+		if part != pathParts[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+//nolint:cyclop
+func validateSchema(t *testing.T, spec openAPI, schema map[string]interface{}, value interface{}, location string) {
+	t.Helper()
+	if ref, ok := schema["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/components/schemas/")
+		resolved, ok := spec.Components.Schemas[name]
+		require.True(t, ok, "%s: unknown schema %s", location, ref)
+		validateSchema(t, spec, resolved, value, location)
+
+		return
+	}
+
+	switch schema["type"] {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		require.True(t, ok, "%s: object expected", location)
+		properties, _ := schema["properties"].(map[string]interface{})
+		required, _ := schema["required"].([]interface{})
+		for _, name := range required {
+			require.Contains(t, object, name, "%s: required field missed", location)
+		}
+		for name, fieldValue := range object {
+			fieldSchema, ok := properties[name].(map[string]interface{})
+			require.True(t, ok, "%s: field %s not documented", location, name)
+			validateSchema(t, spec, fieldSchema, fieldValue, location+"."+name)
+		}
+	case "array":
+		array, ok := value.([]interface{})
+		require.True(t, ok, "%s: array expected", location)
+		items, _ := schema["items"].(map[string]interface{})
+		for _, item := range array {
+			validateSchema(t, spec, items, item, location+"[]")
+		}
+	case "string":
+		str, ok := value.(string)
+		require.True(t, ok, "%s: string expected", location)
+		if enum, ok := schema["enum"].([]interface{}); ok {
+			require.Contains(t, enum, str, "%s: unknown enum value", location)
+		}
+	case "integer", "number":
+		_, ok := value.(float64)
+		require.True(t, ok, "%s: number expected", location)
+	case "boolean":
+		_, ok := value.(bool)
+		require.True(t, ok, "%s: boolean expected", location)
+	default:
+		require.Failf(t, "unsupported schema", "%s: %v", location, schema)
+	}
+}
@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+const suggestionsLimit = 10
+
+type openSearchDescription struct {
+	XMLName       xml.Name        `xml:"http://a9.com/-/spec/opensearch/1.1/ OpenSearchDescription"`
+	ShortName     string          `xml:"ShortName"`
+	Description   string          `xml:"Description"`
+	InputEncoding string          `xml:"InputEncoding"`
+	Image         openSearchImage `xml:"Image"`
+	URLs          []openSearchURL `xml:"Url"`
+}
+
+type openSearchImage struct {
+	Width  int    `xml:"width,attr"`
+	Height int    `xml:"height,attr"`
+	Type   string `xml:"type,attr"`
+	URL    string `xml:",chardata"`
+}
+
+type openSearchURL struct {
+	Type     string `xml:"type,attr"`
+	Method   string `xml:"method,attr,omitempty"`
+	Rel      string `xml:"rel,attr,omitempty"`
+	Template string `xml:"template,attr"`
+}
+
+func (svc *Service) getOpenSearch(writer http.ResponseWriter, request *http.Request, _ httprouter.Params) {
+	base := serverURL(request)
+	description := openSearchDescription{
+		ShortName:     svc.branding.Name(),
+		Description:   "Search ingresses and links in dashboard",
+		InputEncoding: "UTF-8",
+		Image:         openSearchImage{Width: 16, Height: 16, Type: "image/x-icon", URL: base + "/favicon.ico"},
+		URLs: []openSearchURL{
+			{Type: "text/html", Method: "get", Template: base + "/search?q={searchTerms}"},
+			{Type: "application/x-suggestions+json", Method: "get", Template: base + "/search/suggest?q={searchTerms}"},
+			{Type: "application/opensearchdescription+xml", Rel: "self", Template: base + "/opensearch.xml"},
+		},
+	}
+	writer.Header().Set("Content-Type", "application/opensearchdescription+xml")
+	_, _ = writer.Write([]byte(xml.Header))
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	_ = encoder.Encode(description)
+}
+
+// getSearch redirects to the first URL of the found entry if there is only one match (or only one entry has exactly
+// the same title or name as query). Otherwise, filtered index page is shown.
+func (svc *Service) getSearch(writer http.ResponseWriter, request *http.Request, _ httprouter.Params) {
+	filter := ParseFilter(request.URL.Query())
+	if filter.Query != "" {
+		if target, ok := singleMatch(filter.Search(visibleIngresses(svc.getList())), filter.Query); ok && len(target.Refs) > 0 {
+			http.Redirect(writer, request, target.Refs[0].URL, http.StatusFound)
+
+			return
+		}
+	}
+	svc.renderIndex(writer, request, filter)
+}
+
+// getSuggestions in OpenSearch suggestions format: [query, [completions], [descriptions], [urls]].
+func (svc *Service) getSuggestions(writer http.ResponseWriter, request *http.Request, _ httprouter.Params) {
+	query := request.URL.Query().Get("q")
+	var completions, descriptions, urls = []string{}, []string{}, []string{}
+	if strings.TrimSpace(query) != "" {
+		found := Filter{Query: query}.Search(visibleIngresses(svc.getList()))
+		if len(found) > suggestionsLimit {
+			found = found[:suggestionsLimit]
+		}
+		for _, ing := range found {
+			var link string
+			if len(ing.Refs) > 0 {
+				link = ing.Refs[0].URL
+			}
+			completions = append(completions, ing.Label())
+			descriptions = append(descriptions, ing.Description)
+			urls = append(urls, link)
+		}
+	}
+
+	writer.Header().Set("Content-Type", "application/x-suggestions+json")
+	if err := json.NewEncoder(writer).Encode([]interface{}{query, completions, descriptions, urls}); err != nil {
+		log.Println("failed encode suggestions:", err)
+	}
+}
+
+func singleMatch(found []Ingress, query string) (Ingress, bool) {
+	if len(found) == 1 {
+		return found[0], true
+	}
+	query = strings.TrimSpace(query)
+	var exact []Ingress
+	for _, ing := range found {
+		if strings.EqualFold(ing.Label(), query) || strings.EqualFold(ing.Name, query) {
+			exact = append(exact, ing)
+		}
+	}
+	if len(exact) == 1 {
+		return exact[0], true
+	}
+
+	return Ingress{}, false
+}
+
+// serverURL as seen by client, respecting reverse-proxy headers.
+func serverURL(request *http.Request) string {
+	host := request.Host
+	if v := request.Header.Get("X-Forwarded-Host"); v != "" {
+		host = v
+	}
+	proto := "http"
+	if v := request.Header.Get("X-Forwarded-Proto"); v != "" {
+		proto = v
+	} else if request.TLS != nil {
+		proto = "https"
+	}
+
+	return proto + "://" + host
+}
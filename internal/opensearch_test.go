@@ -0,0 +1,65 @@
+package internal
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_openSearch(t *testing.T) {
+	svc := New()
+	svc.Set(searchFixture())
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-Forwarded-Proto", "https")
+		req.Host = "dashboard.example.com"
+		res := httptest.NewRecorder()
+		svc.ServeHTTP(res, req)
+
+		return res
+	}
+
+	t.Run("description", func(t *testing.T) {
+		res := get("/opensearch.xml")
+		require.Equal(t, http.StatusOK, res.Code)
+		require.Equal(t, "application/opensearchdescription+xml", res.Header().Get("Content-Type"))
+		require.Contains(t, res.Body.String(), `template="https://dashboard.example.com/search?q={searchTerms}"`)
+		require.Contains(t, res.Body.String(), `template="https://dashboard.example.com/search/suggest?q={searchTerms}"`)
+	})
+
+	t.Run("single match redirects", func(t *testing.T) {
+		res := get("/search?q=prom+monitoring")
+		require.Equal(t, http.StatusFound, res.Code)
+		require.Equal(t, "http://prometheus.example.com", res.Header().Get("Location"))
+	})
+
+	t.Run("exact name redirects", func(t *testing.T) {
+		res := get("/search?q=Grafana")
+		require.Equal(t, http.StatusFound, res.Code)
+		require.Equal(t, "https://grafana.example.com", res.Header().Get("Location"))
+	})
+
+	t.Run("multiple matches render index", func(t *testing.T) {
+		res := get("/search?q=example")
+		require.Equal(t, http.StatusOK, res.Code)
+		require.Contains(t, res.Body.String(), "found 3 of 4")
+	})
+
+	t.Run("suggestions", func(t *testing.T) {
+		res := get("/search/suggest?q=graf")
+		require.Equal(t, http.StatusOK, res.Code)
+		require.Equal(t, "application/x-suggestions+json", res.Header().Get("Content-Type"))
+		var suggestions []interface{}
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&suggestions))
+		require.Equal(t, []interface{}{
+			"graf",
+			[]interface{}{"grafana-dev", "grafana", "prometheus", "docs"},
+			[]interface{}{"", "", "", "Grafana and Prometheus guides"},
+			[]interface{}{"http://grafana.dev.example.com", "https://grafana.example.com", "http://prometheus.example.com", ""},
+		}, suggestions)
+	})
+}
@@ -0,0 +1,198 @@
+package internal
+
+import (
+	"log"
+	"net/http"
+	"sort"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/reddec/ingress-dashboard/internal/auth"
+)
+
+// Severity of problem. Higher is worse.
+type Severity int
+
+const (
+	SeverityInfo     Severity = iota + 1 // cosmetic, for example missing logo
+	SeverityWarning                      // needs attention soon
+	SeverityCritical                     // users are affected now
+)
+
+// Name of severity: info, warning or critical.
+func (s Severity) Name() string {
+	switch s {
+	case SeverityCritical:
+		return "critical"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// Kinds of problems.
+const (
+	ProblemTLSExpired    = "tls_expired"     // certificate expired
+	ProblemTLSSoonExpire = "tls_soon_expire" // certificate expires soon
+	ProblemDeadRefs      = "dead_refs"       // links without ready hosts
+	ProblemNoClass       = "no_class"        // ingress without class
+	ProblemCertError     = "cert_error"      // failed to fetch certificate
+	ProblemLogoError     = "logo_error"      // failed to discover logo
+)
+
+const (
+	sortParam     = "sort"
+	SortSeverity  = "severity"
+	SortNamespace = "namespace"
+)
+
+// Problem of entry.
+type Problem struct {
+	Kind     string
+	Severity Severity
+	Detail   string // optional details: error or expiration time
+}
+
+// UIProblem is entry with all its problems.
+type UIProblem struct {
+	Ingress  Ingress
+	Problems []Problem // sorted by severity, the worst first
+	Severity Severity  // the worst severity
+}
+
+type UIProblemsContext struct {
+	UIContext
+	Entries []UIProblem
+	Sort    string
+	Sorts   []ViewMode // links to the same page with different sorting
+}
+
+// problemsOf entry. Hidden entries are not checked by caller.
+func problemsOf(ingress Ingress) []Problem {
+	var ans []Problem
+	switch ingress.TLSStatus() {
+	case TLSExpired:
+		ans = append(ans, Problem{Kind: ProblemTLSExpired, Severity: SeverityCritical})
+	case TLSSoonExpire:
+		ans = append(ans, Problem{Kind: ProblemTLSSoonExpire, Severity: SeverityWarning})
+	}
+	if ingress.HasDeadRefs() {
+		ans = append(ans, Problem{Kind: ProblemDeadRefs, Severity: SeverityCritical})
+	}
+	if !ingress.Static && ingress.Class == "" {
+		ans = append(ans, Problem{Kind: ProblemNoClass, Severity: SeverityWarning})
+	}
+	if ingress.TLS && ingress.CertError != "" {
+		ans = append(ans, Problem{Kind: ProblemCertError, Severity: SeverityWarning, Detail: ingress.CertError})
+	}
+	if ingress.LogoURL == "" && ingress.LogoError != "" {
+		ans = append(ans, Problem{Kind: ProblemLogoError, Severity: SeverityInfo, Detail: ingress.LogoError})
+	}
+	sort.SliceStable(ans, func(i, j int) bool {
+		return ans[i].Severity > ans[j].Severity
+	})
+
+	return ans
+}
+
+// findProblems returns entries with at least one problem, sorted by mode (severity by default).
+func findProblems(list []Ingress, mode string) []UIProblem {
+	var ans []UIProblem
+	for _, ing := range list {
+		problems := problemsOf(ing)
+		if len(problems) == 0 {
+			continue
+		}
+		ans = append(ans, UIProblem{Ingress: ing, Problems: problems, Severity: problems[0].Severity})
+	}
+
+	bySeverity := func(a, b UIProblem) (less, equal bool) {
+		if a.Severity != b.Severity {
+			return a.Severity > b.Severity, false
+		}
+		if len(a.Problems) != len(b.Problems) {
+			return len(a.Problems) > len(b.Problems), false
+		}
+
+		return false, true
+	}
+	sort.SliceStable(ans, func(i, j int) bool {
+		a, b := ans[i], ans[j]
+		if mode == SortNamespace && a.Ingress.Namespace != b.Ingress.Namespace {
+			return a.Ingress.Namespace < b.Ingress.Namespace
+		}
+		if less, equal := bySeverity(a, b); !equal {
+			return less
+		}
+		if a.Ingress.Namespace != b.Ingress.Namespace {
+			return a.Ingress.Namespace < b.Ingress.Namespace
+		}
+
+		return a.Ingress.Label() < b.Ingress.Label()
+	})
+
+	return ans
+}
+
+// countProblems is number of entries with warnings or critical problems. Info problems are not counted:
+// they are shown on problems page, but should not attract attention.
+func countProblems(list []Ingress) int {
+	var count int
+	for _, ing := range list {
+		if problems := problemsOf(ing); len(problems) > 0 && problems[0].Severity >= SeverityWarning {
+			count++
+		}
+	}
+
+	return count
+}
+
+func parseSort(value string) string {
+	if value == SortNamespace {
+		return SortNamespace
+	}
+
+	return SortSeverity
+}
+
+func sortModes(active string) []ViewMode {
+	var ans []ViewMode
+	for _, mode := range []string{SortSeverity, SortNamespace} {
+		u := "?" + sortParam + "=" + mode
+		if mode == SortSeverity {
+			u = "./problems"
+		}
+		ans = append(ans, ViewMode{Label: mode, Mode: mode, Active: mode == active, URL: u})
+	}
+
+	return ans
+}
+
+func (svc *Service) getProblems(writer http.ResponseWriter, request *http.Request, _ httprouter.Params) {
+	locale := svc.locale(writer, request)
+	list := locale.localize(visibleIngresses(svc.getList()))
+	mode := parseSort(request.URL.Query().Get(sortParam))
+
+	sorts := sortModes(mode)
+	for i := range sorts {
+		sorts[i].Label = locale.T("sort_" + sorts[i].Mode)
+	}
+
+	writer.Header().Set("Content-Type", "text/html")
+	if err := svc.problems.Execute(writer, UIProblemsContext{
+		UIContext: UIContext{
+			Ingresses: list,
+			User:      auth.UserFromContext(request.Context()),
+			Loading:   svc.health.Loading(),
+			Problems:  countProblems(list),
+			Branding:  svc.branding,
+			Locale:    locale,
+			Locales:   svc.locales.list,
+		},
+		Entries: findProblems(list, mode),
+		Sort:    mode,
+		Sorts:   sorts,
+	}); err != nil {
+		log.Println("failed render problems page:", err)
+	}
+}
@@ -0,0 +1,102 @@
+package internal
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func problemsFixture() []Ingress {
+	valid := CertInfo{Expiration: time.Now().Add(2 * SoonExpiredInterval)}
+
+	return []Ingress{
+		{UID: "ok", Name: "ok", Namespace: "a", Class: "nginx", TLS: true, Cert: valid, Refs: []Ref{{Pods: 1}}},
+		{UID: "logo", Name: "logo", Namespace: "a", Class: "nginx", Refs: []Ref{{Pods: 1}}, LogoError: "no logo in meta"},
+		{UID: "soon", Name: "soon", Namespace: "a", TLS: true, Cert: CertInfo{Expiration: time.Now().Add(time.Hour)}, Refs: []Ref{{Pods: 1}}},
+		{UID: "expired", Name: "expired", Namespace: "b", Class: "nginx", TLS: true, Cert: CertInfo{Expiration: time.Now().Add(-time.Hour)}, Refs: []Ref{{Pods: 1}}},
+		{UID: "dead", Name: "dead", Namespace: "a", Class: "nginx", TLS: true, Cert: valid, Refs: []Ref{{}}, CertError: "timeout"},
+	}
+}
+
+func TestProblemsOf(t *testing.T) {
+	list := problemsFixture()
+
+	require.Empty(t, problemsOf(list[0]))
+	require.Equal(t, []Problem{{Kind: ProblemLogoError, Severity: SeverityInfo, Detail: "no logo in meta"}}, problemsOf(list[1]))
+	require.Equal(t, []Problem{
+		{Kind: ProblemTLSSoonExpire, Severity: SeverityWarning},
+		{Kind: ProblemNoClass, Severity: SeverityWarning},
+	}, problemsOf(list[2]))
+	require.Equal(t, []Problem{
+		{Kind: ProblemDeadRefs, Severity: SeverityCritical},
+		{Kind: ProblemCertError, Severity: SeverityWarning, Detail: "timeout"},
+	}, problemsOf(list[4]))
+
+	// discovered logo hides old error, static entries do not need class
+	require.Empty(t, problemsOf(Ingress{LogoURL: "/favicon.ico", LogoError: "timeout", Static: true}))
+}
+
+func TestFindProblems(t *testing.T) {
+	list := problemsFixture()
+
+	entries := func(found []UIProblem) []string {
+		var ans []string
+		for _, p := range found {
+			ans = append(ans, p.Ingress.UID)
+		}
+
+		return ans
+	}
+
+	// critical with more problems first
+	require.Equal(t, []string{"dead", "expired", "soon", "logo"}, entries(findProblems(list, SortSeverity)))
+	require.Equal(t, []string{"dead", "soon", "logo", "expired"}, entries(findProblems(list, SortNamespace)))
+	require.Equal(t, 3, countProblems(list))
+}
+
+func TestService_problems(t *testing.T) {
+	svc := New()
+	svc.Set(problemsFixture())
+
+	res := httptest.NewRecorder()
+	svc.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/problems?sort=namespace", nil))
+	require.Equal(t, http.StatusOK, res.Code)
+	body := res.Body.String()
+	require.Contains(t, body, `<a href="details/dead">dead</a>`)
+	require.Contains(t, body, `<small>timeout</small>`)
+	require.Contains(t, body, `<b>by namespace</b>`)
+	require.NotContains(t, body, `href="details/ok"`)
+
+	res = httptest.NewRecorder()
+	svc.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Contains(t, res.Body.String(), `⚠ 3 problems</a>`)
+
+	svc.Set(problemsFixture()[:1])
+	res = httptest.NewRecorder()
+	svc.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/details/ok", nil))
+	require.NotContains(t, res.Body.String(), `class="problems-badge"`)
+}
+
+func TestEnricher_errors(t *testing.T) {
+	en := newEnricher(ReceiverFunc(func([]Ingress) {}))
+	en.upsert(Ingress{UID: "a", TLS: true})
+	en.updateCertError("a", errors.New("timeout"))
+	en.updateLogoError("a", errors.New("no logo"))
+
+	// errors are kept after update of object
+	en.upsert(Ingress{UID: "a", TLS: true})
+	item := en.items()[0]
+	require.Equal(t, "timeout", item.CertError)
+	require.Equal(t, "no logo", item.LogoError)
+
+	// and reset by successful discovery
+	en.updateCertInfo(Ingress{UID: "a", Cert: CertInfo{Expiration: time.Now()}})
+	en.updateLogo(Ingress{UID: "a", LogoURL: "/favicon.ico"})
+	item = en.items()[0]
+	require.Empty(t, item.CertError)
+	require.Empty(t, item.LogoError)
+}
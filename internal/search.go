@@ -0,0 +1,285 @@
+package internal
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// weights of fields and kinds of matches used for relevance.
+const (
+	weightName      = 10
+	weightTag       = 6
+	weightNamespace = 4
+	weightHost      = 3
+	weightText      = 1 // description and full URLs
+	matchExact      = 4
+	matchPrefix     = 2
+	matchSubstring  = 1
+)
+
+// query parameters of facets.
+const (
+	facetNamespace = "namespace"
+	facetClass     = "class"
+	facetTLS       = "tls"
+	facetDead      = "dead"
+)
+
+type textField struct {
+	value  string
+	weight int
+}
+
+// textFields of ingress used for text search.
+func textFields(ingress Ingress) []textField {
+	fields := []textField{
+		{value: ingress.Title, weight: weightName},
+		{value: ingress.Name, weight: weightName},
+		{value: ingress.Namespace, weight: weightNamespace},
+		{value: ingress.Description, weight: weightText},
+	}
+	for _, tag := range ingress.Tags {
+		fields = append(fields, textField{value: tag, weight: weightTag})
+	}
+	for _, ref := range ingress.Refs {
+		fields = append(fields, textField{value: hostname(ref.URL), weight: weightHost}, textField{value: ref.URL, weight: weightText})
+	}
+
+	return fields
+}
+
+// relevance of ingress for query terms: sum of best matches of each term. Exact matches and matches in names
+// are more relevant than partial matches in description.
+func relevance(ingress Ingress, terms []string) int {
+	fields := textFields(ingress)
+	var score int
+	for _, term := range terms {
+		var best int
+		for _, field := range fields {
+			value := strings.ToLower(field.value)
+			var kind int
+			switch {
+			case value == term:
+				kind = matchExact
+			case strings.HasPrefix(value, term):
+				kind = matchPrefix
+			case strings.Contains(value, term):
+				kind = matchSubstring
+			}
+			if s := kind * field.weight; s > best {
+				best = s
+			}
+		}
+		score += best
+	}
+
+	return score
+}
+
+// Search applies filter and sorts results by relevance to the query. Without query original order is kept.
+func (filter Filter) Search(list []Ingress) []Ingress {
+	found := filter.Apply(list)
+	terms := filter.terms()
+	if len(terms) == 0 {
+		return found
+	}
+	type scored struct {
+		ingress Ingress
+		score   int
+	}
+	var ranked = make([]scored, 0, len(found))
+	for _, ing := range found {
+		ranked = append(ranked, scored{ingress: ing, score: relevance(ing, terms)})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].score > ranked[j].score
+	})
+	for i, item := range ranked {
+		found[i] = item.ingress
+	}
+
+	return found
+}
+
+// Facet is group of filter values with number of matched entries.
+type Facet struct {
+	Title  string
+	Param  string // query parameter
+	Values []FacetValue
+}
+
+// FacetValue is single value of facet. URL toggles value in current filter.
+type FacetValue struct {
+	Label  string
+	Value  string // value of query parameter
+	Count  int
+	Active bool
+	URL    string
+}
+
+// QueryParam is single query parameter.
+type QueryParam struct {
+	Name  string
+	Value string
+}
+
+// facets of list for namespace, class, TLS status and dead references. Counts are calculated
+// as if facet itself is not filtered.
+func facets(list []Ingress, filter Filter) []Facet {
+	values := filter.Values()
+
+	withoutNamespaces := filter
+	withoutNamespaces.Namespaces = nil
+	withoutClasses := filter
+	withoutClasses.Classes = nil
+	withoutTLS := filter
+	withoutTLS.TLS = nil
+	withoutDead := filter
+	withoutDead.Dead = nil
+
+	var namespaces, classes = make(map[string]int), make(map[string]int)
+	var tls = make(map[TLSStatus]int)
+	var dead = make(map[bool]int)
+	for _, ing := range list {
+		if ing.Namespace != "" && withoutNamespaces.Match(ing) {
+			namespaces[ing.Namespace]++
+		}
+		if ing.Class != "" && withoutClasses.Match(ing) {
+			classes[ing.Class]++
+		}
+		if withoutTLS.Match(ing) {
+			tls[ing.TLSStatus()]++
+		}
+		if withoutDead.Match(ing) {
+			dead[ing.HasDeadRefs()]++
+		}
+	}
+	// active values are always visible
+	for _, ns := range filter.Namespaces {
+		namespaces[ns] += 0
+	}
+	for _, class := range filter.Classes {
+		classes[class] += 0
+	}
+
+	var ans = []Facet{
+		{Title: "Namespace", Param: facetNamespace, Values: stringFacetValues(values, facetNamespace, namespaces)},
+		{Title: "Class", Param: facetClass, Values: stringFacetValues(values, facetClass, classes)},
+	}
+
+	var tlsFacet = Facet{Title: "TLS", Param: facetTLS}
+	for _, status := range []TLSStatus{TLSValid, TLSSoonExpire, TLSExpired, TLSUnknown, TLSDisabled} {
+		if tls[status] == 0 && !containsTLS(filter.TLS, status) {
+			continue
+		}
+		tlsFacet.Values = append(tlsFacet.Values, FacetValue{
+			Label:  tlsLabel(status),
+			Value:  string(status),
+			Count:  tls[status],
+			Active: containsTLS(filter.TLS, status),
+			URL:    toggleURL(values, facetTLS, string(status), false),
+		})
+	}
+	ans = append(ans, tlsFacet)
+
+	var deadFacet = Facet{Title: "Hosts", Param: facetDead}
+	for _, hasDead := range []bool{false, true} {
+		active := filter.Dead != nil && *filter.Dead == hasDead
+		if dead[hasDead] == 0 && !active {
+			continue
+		}
+		label := "all hosts available"
+		if hasDead {
+			label = "no hosts"
+		}
+		deadFacet.Values = append(deadFacet.Values, FacetValue{
+			Label:  label,
+			Value:  strconv.FormatBool(hasDead),
+			Count:  dead[hasDead],
+			Active: active,
+			URL:    toggleURL(values, facetDead, strconv.FormatBool(hasDead), true),
+		})
+	}
+	ans = append(ans, deadFacet)
+
+	return ans
+}
+
+func stringFacetValues(values url.Values, param string, counts map[string]int) []FacetValue {
+	var ans = make([]FacetValue, 0, len(counts))
+	for value, count := range counts {
+		ans = append(ans, FacetValue{
+			Label:  value,
+			Value:  value,
+			Count:  count,
+			Active: contains(values[param], value),
+			URL:    toggleURL(values, param, value, false),
+		})
+	}
+	sort.Slice(ans, func(i, j int) bool {
+		return ans[i].Label < ans[j].Label
+	})
+
+	return ans
+}
+
+// toggleURL returns relative URL with added (or removed, if already set) value of parameter.
+// Single parameter can have only one value.
+func toggleURL(values url.Values, param, value string, single bool) string {
+	var cp = make(url.Values, len(values))
+	for k, v := range values {
+		cp[k] = append([]string(nil), v...)
+	}
+	switch {
+	case contains(cp[param], value):
+		var rest []string
+		for _, v := range cp[param] {
+			if v != value {
+				rest = append(rest, v)
+			}
+		}
+		cp[param] = rest
+	case single:
+		cp.Set(param, value)
+	default:
+		cp.Add(param, value)
+	}
+	if encoded := cp.Encode(); encoded != "" {
+		return "./?" + encoded
+	}
+
+	return "./"
+}
+
+// hiddenParams returns filter parameters (except query) to be preserved by search form.
+func hiddenParams(filter Filter) []QueryParam {
+	var ans []QueryParam
+	values := filter.Values()
+	values.Del("q")
+	for _, name := range []string{facetNamespace, facetClass, "tag", facetTLS, facetDead} {
+		for _, value := range values[name] {
+			ans = append(ans, QueryParam{Name: name, Value: value})
+		}
+	}
+
+	return ans
+}
+
+func tlsLabel(status TLSStatus) string {
+	switch status {
+	case TLSValid:
+		return "valid"
+	case TLSSoonExpire:
+		return "soon expire"
+	case TLSExpired:
+		return "expired"
+	case TLSUnknown:
+		return "unknown"
+	case TLSDisabled:
+		return "not enabled"
+	default:
+		return string(status)
+	}
+}
@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func searchFixture() []Ingress {
+	return []Ingress{
+		{UID: "docs", Name: "docs", Namespace: "external", Description: "Grafana and Prometheus guides", Static: true},
+		{UID: "grafana-dev", Name: "grafana-dev", Namespace: "dev", Class: "nginx", Refs: []Ref{{URL: "http://grafana.dev.example.com", Pods: 1}}},
+		{UID: "grafana", Name: "grafana", Namespace: "monitoring", Class: "nginx", TLS: true, Refs: []Ref{{URL: "https://grafana.example.com", Pods: 1}}},
+		{UID: "prometheus", Name: "prometheus", Namespace: "monitoring", Class: "traefik", Tags: []string{"grafana-datasource"}, Refs: []Ref{{URL: "http://prometheus.example.com"}}},
+	}
+}
+
+func uids(list []Ingress) []string {
+	var ans []string
+	for _, ing := range list {
+		ans = append(ans, ing.UID)
+	}
+
+	return ans
+}
+
+func TestFilter_Search(t *testing.T) {
+	list := searchFixture()
+
+	t.Run("no query keeps order", func(t *testing.T) {
+		require.Equal(t, []string{"grafana-dev", "grafana"}, uids(Filter{Classes: []string{"nginx"}}.Search(list)))
+	})
+
+	t.Run("ranked by relevance", func(t *testing.T) {
+		// exact name, name prefix, tag prefix, description
+		require.Equal(t, []string{"grafana", "grafana-dev", "prometheus", "docs"}, uids(Filter{Query: "Grafana"}.Search(list)))
+	})
+
+	t.Run("all words should match", func(t *testing.T) {
+		require.Equal(t, []string{"grafana", "prometheus"}, uids(Filter{Query: "grafana monitoring"}.Search(list)))
+	})
+
+	t.Run("by host", func(t *testing.T) {
+		require.Equal(t, []string{"grafana-dev"}, uids(Filter{Query: "grafana.dev.example"}.Search(list)))
+	})
+}
+
+func TestFacets(t *testing.T) {
+	list := searchFixture()
+	filter := ParseFilter(url.Values{"namespace": {"monitoring"}, "q": {"grafana"}})
+
+	result := facets(list, filter)
+	require.Len(t, result, 4)
+
+	namespaces := result[0]
+	require.Equal(t, "Namespace", namespaces.Title)
+	require.Equal(t, []FacetValue{
+		{Label: "dev", Value: "dev", Count: 1, URL: "./?namespace=monitoring&namespace=dev&q=grafana"},
+		{Label: "external", Value: "external", Count: 1, URL: "./?namespace=monitoring&namespace=external&q=grafana"},
+		{Label: "monitoring", Value: "monitoring", Count: 2, Active: true, URL: "./?q=grafana"},
+	}, namespaces.Values)
+
+	classes := result[1]
+	require.Equal(t, []FacetValue{
+		{Label: "nginx", Value: "nginx", Count: 1, URL: "./?class=nginx&namespace=monitoring&q=grafana"},
+		{Label: "traefik", Value: "traefik", Count: 1, URL: "./?class=traefik&namespace=monitoring&q=grafana"},
+	}, classes.Values)
+
+	dead := result[3]
+	require.Equal(t, []FacetValue{
+		{Label: "all hosts available", Value: "false", Count: 1, URL: "./?dead=false&namespace=monitoring&q=grafana"},
+		{Label: "no hosts", Value: "true", Count: 1, URL: "./?dead=true&namespace=monitoring&q=grafana"},
+	}, dead.Values)
+
+	require.Equal(t, []QueryParam{{Name: "namespace", Value: "monitoring"}}, hiddenParams(filter))
+	require.Equal(t, "./", toggleURL(url.Values{"dead": {"true"}}, "dead", "true", true))
+	require.Equal(t, "./?dead=false", toggleURL(url.Values{"dead": {"true"}}, "dead", "false", true))
+}
+
+func TestService_getIndex_search(t *testing.T) {
+	svc := New()
+	svc.Set(searchFixture())
+
+	res := httptest.NewRecorder()
+	svc.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/?q=prometheus&class=traefik", nil))
+	require.Equal(t, http.StatusOK, res.Code)
+	body := res.Body.String()
+	require.Contains(t, body, `value="prometheus"`)
+	require.Contains(t, body, `<input type="hidden" name="class" value="traefik">`)
+	require.Contains(t, body, "found 1 of 4")
+	require.Contains(t, body, `href="details/prometheus"`)
+	require.NotContains(t, body, `href="details/docs"`)
+}
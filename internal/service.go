@@ -1,6 +1,9 @@
 package internal
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"html/template"
@@ -11,12 +14,16 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
+	textTemplate "text/template"
 	"time"
 
 	"github.com/hako/durafmt"
 	"github.com/julienschmidt/httprouter"
+	"github.com/reddec/ingress-dashboard/api"
 	"github.com/reddec/ingress-dashboard/internal/auth"
 	"github.com/reddec/ingress-dashboard/internal/static"
 	"gopkg.in/yaml.v3"
@@ -24,22 +31,31 @@ import (
 
 const (
 	SoonExpiredInterval = 14 * 24 * time.Hour // 2 weeks
+	staticUIDSize       = 8                   // bytes of hash used for static UID
 )
 
 type Ingress struct {
-	ID          string   `yaml:"-"`           // human readable ID (namespace with name)
-	UID         string   `yaml:"-"`           // machine readable ID (guid in Kube)
-	Title       string   `yaml:"-"`           // custom title in dashboard, overwrites Name
-	Name        string   `yaml:"name"`        // ingress name as in Kube
-	Namespace   string   `yaml:"namespace"`   // Kube namespace for ingress
-	Description string   `yaml:"description"` // optional, human-readable description of Ingress
-	Hide        bool     `yaml:"-"`           // hidden Ingresses will not appear in UI
-	LogoURL     string   `yaml:"logo_url"`    // custom URL for icon
-	Class       string   `yaml:"-"`           // Ingress class
-	Static      bool     `yaml:"-"`
-	Refs        []Ref    `yaml:"-"`
-	TLS         bool     `yaml:"-"`
-	Cert        CertInfo `yaml:"-"`
+	ID          string   `yaml:"-"`                      // human readable ID (namespace with name)
+	UID         string   `yaml:"-"`                      // machine readable ID (guid in Kube, generated for static)
+	Title       string   `yaml:"title,omitempty"`        // custom title in dashboard, overwrites Name
+	Name        string   `yaml:"name"`                   // ingress name as in Kube
+	Namespace   string   `yaml:"namespace,omitempty"`    // Kube namespace for ingress
+	Description string   `yaml:"description,omitempty"`  // optional, human-readable description of Ingress
+	Hide        bool     `yaml:"hide,omitempty"`         // hidden Ingresses will not appear in UI
+	LogoURL     string   `yaml:"logo_url,omitempty"`     // custom URL for icon
+	LogoFound   bool     `yaml:"-"`                      // LogoURL is discovered automatically, not set by user
+	Class       string   `yaml:"-"`                      // Ingress class
+	Tags        []string `yaml:"tags,omitempty"`         // optional list of tags
+	Alias       string   `yaml:"alias,omitempty"`        // short name for /go/ links, Name is used by default
+	PublicBadge bool     `yaml:"public_badge,omitempty"` // status badges are available without authorization
+	// descriptions in other languages by language code (de, ru, ...)
+	Descriptions map[string]string `yaml:"descriptions,omitempty"`
+	Static       bool              `yaml:"-"`
+	Refs         []Ref             `yaml:"-"`
+	TLS          bool              `yaml:"-"` // TLS enabled (detected by URLs for static definitions)
+	Cert         CertInfo          `yaml:"-"`
+	CertError    string            `yaml:"-"` // last error of certificate fetching, empty if succeeded
+	LogoError    string            `yaml:"-"` // last error of logo discovery, empty if succeeded or not needed
 }
 
 type Ref struct {
@@ -70,6 +86,28 @@ func (ingress Ingress) Logo() string {
 	return ingress.LogoURL
 }
 
+// Hosts are unique host names (in lower case) of links.
+func (ingress Ingress) Hosts() []string {
+	var hosts []string
+	for _, ref := range ingress.Refs {
+		if host := strings.ToLower(hostname(ref.URL)); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+
+	return uniqueStrings(hosts)
+}
+
+// Pods is total number of hosts serving links.
+func (ingress Ingress) Pods() int {
+	var total int
+	for _, ref := range ingress.Refs {
+		total += ref.Pods
+	}
+
+	return total
+}
+
 func (ingress Ingress) HasDeadRefs() bool {
 	for _, ref := range ingress.Refs {
 		if !ref.Static && ref.Pods == 0 {
@@ -92,9 +130,99 @@ func (ingress Ingress) WhenTLSExpires() string {
 	return durafmt.Parse(time.Until(ingress.Cert.Expiration)).String()
 }
 
+// TLSStatus returns summarized state of TLS: disabled, unknown (not yet checked), expired, soon-expire or valid.
+func (ingress Ingress) TLSStatus() TLSStatus {
+	switch {
+	case !ingress.TLS:
+		return TLSDisabled
+	case ingress.Cert.Expiration.IsZero():
+		return TLSUnknown
+	case ingress.IsTLSExpired():
+		return TLSExpired
+	case ingress.IsTLSSoonExpire():
+		return TLSSoonExpire
+	default:
+		return TLSValid
+	}
+}
+
+type TLSStatus = api.TLSStatus
+
+const (
+	TLSDisabled   = api.TLSDisabled
+	TLSUnknown    = api.TLSUnknown
+	TLSExpired    = api.TLSExpired
+	TLSSoonExpire = api.TLSSoonExpire
+	TLSValid      = api.TLSValid
+)
+
 type UIContext struct {
 	Ingresses []Ingress
 	User      *auth.User
+	Loading   bool // initial sync of sources is not yet completed
+	Problems  int  // number of entries with problems (warnings or critical)
+	Search    UISearch
+	Group     string     // group mode of index page, empty if not grouped
+	Groups    []UIGroup  // sections of index page, empty if not grouped
+	Views     []ViewMode // links to the same page with different grouping
+	Layout    string     // layout of index page: cards or table
+	Layouts   []ViewMode // links to the same page with different layout
+	Columns   []UIColumn // columns of table with links to sort by them
+	Branding  Branding
+	Locale    *Locale   // language of page
+	Locales   []*Locale // all supported languages
+	// favourite entries of user, shown at the top of index page (only if search is not active)
+	Favourites []Ingress
+	starred    map[string]bool // IDs of favourite entries
+}
+
+// UICard is context of entry card.
+type UICard struct {
+	Ingress   Ingress
+	Locale    *Locale
+	Favourite bool // entry is in favourites of user
+}
+
+// Card context for entry.
+func (ui UIContext) Card(ingress Ingress) UICard {
+	return UICard{Ingress: ingress, Locale: ui.Locale, Favourite: ui.IsFavourite(ingress)}
+}
+
+// UITable is context of table with entries.
+type UITable struct {
+	UIContext
+	Rows []Ingress
+}
+
+// Table context for entries.
+func (ui UIContext) Table(list []Ingress) UITable {
+	return UITable{UIContext: ui, Rows: list}
+}
+
+// IsFavourite returns true if entry is in favourites of user.
+func (ui UIContext) IsFavourite(ingress Ingress) bool {
+	return ui.starred[ingress.ID]
+}
+
+// withFavourites of user.
+func (ui UIContext) withFavourites(list []Ingress, ids []string) UIContext {
+	ui.Favourites = favouriteEntries(list, ids)
+	ui.starred = make(map[string]bool, len(ids))
+	for _, id := range ids {
+		ui.starred[id] = true
+	}
+
+	return ui
+}
+
+// UISearch is state of search form on index page.
+type UISearch struct {
+	Query    string
+	Params   []QueryParam // active filters (except query) preserved by search form
+	Facets   []Facet
+	Filtered bool   // filter is not empty
+	Total    int    // number of entries before filtering
+	ResetURL string // link to the same view without filter
 }
 
 type UIDetailsContext struct {
@@ -104,36 +232,149 @@ type UIDetailsContext struct {
 	Namespaces  []string
 }
 
+// Options of UI.
+type Options struct {
+	TemplatesDir string // directory with templates overriding embedded templates with the same name
+	AssetsDir    string // directory with static files overriding embedded files with the same name
+	Branding     Branding
+}
+
+// New service with embedded templates and assets.
 func New() *Service {
+	svc, err := NewWithOptions(Options{})
+	if err != nil {
+		panic(err) // embedded templates are always valid
+	}
+
+	return svc
+}
+
+// NewWithOptions creates service with custom templates and assets. Templates are validated by rendering sample data.
+func NewWithOptions(options Options) (*Service, error) {
+	if err := options.Branding.Validate(); err != nil {
+		return nil, fmt.Errorf("branding: %w", err)
+	}
+	templates, err := static.Overlay(options.TemplatesDir, static.TemplatesFS())
+	if err != nil {
+		return nil, fmt.Errorf("templates: %w", err)
+	}
+	assets, err := static.Overlay(options.AssetsDir, static.Static())
+	if err != nil {
+		return nil, fmt.Errorf("assets: %w", err)
+	}
+
 	var router = httprouter.New()
 	svc := &Service{
-		page:    template.Must(template.ParseFS(static.Templates, "assets/templates/index.gotemplate")),
-		details: template.Must(template.ParseFS(static.Templates, "assets/templates/details.gotemplate")),
-		router:  router,
+		icons:      newIconCache(),
+		router:     router,
+		events:     newEventLog(eventsCapacity),
+		changes:    newChangelog(),
+		favourites: newMemoryFavourites(),
+		health:     NewHealth(),
+		branding:   options.Branding,
+	}
+	if svc.locales, err = loadLocales(static.LocalesFS()); err != nil {
+		return nil, fmt.Errorf("locales: %w", err)
+	}
+	if svc.page, err = parseHTMLTemplate(templates, templateIndex); err != nil {
+		return nil, err
+	}
+	if svc.details, err = parseHTMLTemplate(templates, templateDetails); err != nil {
+		return nil, err
+	}
+	if svc.aliases, err = parseHTMLTemplate(templates, templateAliases); err != nil {
+		return nil, err
+	}
+	if svc.namespace, err = parseHTMLTemplate(templates, templateNamespace); err != nil {
+		return nil, err
+	}
+	if svc.problems, err = parseHTMLTemplate(templates, templateProblems); err != nil {
+		return nil, err
 	}
-	src := static.Static()
-	sfs := http.FS(src)
+	if svc.wall, err = parseHTMLTemplate(templates, templateWall); err != nil {
+		return nil, err
+	}
+	// bookmarks are not HTML: only minimal escaping, otherwise some browsers fail to import
+	if svc.bookmarks, err = parseTextTemplate(templates, templateBookmarks); err != nil {
+		return nil, err
+	}
+	if err := svc.validateTemplates(); err != nil {
+		return nil, err
+	}
+
+	sfs := http.FS(assets)
 	httpFS := http.FileServer(sfs)
-	router.GET("/", svc.getIndex)
-	router.GET("/details/:uid", svc.getDetails)
+	route := func(path string, handle httprouter.Handle) {
+		router.GET(path, instrument(path, handle))
+	}
+	route("/", svc.getIndex)
+	route("/details/:uid", svc.getDetails)
+	route("/namespaces/:ns", svc.getNamespace)
+	route("/problems", svc.getProblems)
+	route("/wall", svc.getWall)
+	route("/badge/:key", svc.getBadge)
+	route("/badge/:key/:name", svc.getBadge)
+	route("/export.yaml", svc.getExport)
+	route("/bookmarks.html", svc.getBookmarks)
+	route("/feed.atom", svc.getFeed)
+	route("/opensearch.xml", svc.getOpenSearch)
+	route("/search", svc.getSearch)
+	route("/search/suggest", svc.getSuggestions)
+	route("/aliases", svc.getAliases)
+	route("/go/:alias", svc.getGoLink)
+	route("/go/:alias/*rest", svc.getGoLink)
+	route("/api/v1/ingresses", svc.apiListIngresses)
+	route("/api/v1/ingresses/:uid", svc.apiGetIngress)
+	route("/api/v1/namespaces", svc.apiListNamespaces)
+	router.POST("/favourites", instrument("/favourites", svc.postFavourites))
+	router.GET("/api/v1/events", svc.apiEvents) // long-living stream, latency has no sense
+	route("/api/openapi.json", func(writer http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+		writer.Header().Set("Content-Type", "application/json")
+		_, _ = writer.Write(static.OpenAPI)
+	})
+	router.Handler(http.MethodGet, "/metrics", MetricsHandler())
+	router.GET("/healthz", svc.getHealthz)
+	router.GET("/readyz", svc.getReadyz)
 	router.GET("/favicon.ico", func(writer http.ResponseWriter, request *http.Request, _ httprouter.Params) {
 		httpFS.ServeHTTP(writer, request)
 	})
 	router.ServeFiles("/static/*filepath", sfs)
 
-	return svc
+	return svc, nil
 }
 
 type Service struct {
-	cache   atomic.Value // []Ingress
-	prepend atomic.Value // []Ingres
-	page    *template.Template
-	details *template.Template
-	router  http.Handler
+	cache        atomic.Value // []Ingress
+	prepend      atomic.Value // []Ingres
+	custom       atomic.Value // []Ingress
+	namespaces   atomic.Value // map[string]Namespace
+	page         *template.Template
+	details      *template.Template
+	aliases      *template.Template
+	namespace    *template.Template
+	problems     *template.Template
+	wall         *template.Template
+	bookmarks    *textTemplate.Template
+	icons        *iconCache
+	router       http.Handler
+	events       *eventLog
+	favourites   FavouritesStore
+	changes      *changelog
+	branding     Branding
+	locales      *locales
+	health       *Health
+	snapshotLock sync.Mutex
+	snapshot     []Ingress // last visible list, used to detect changes
 }
 
 func (svc *Service) Set(ingress []Ingress) {
 	svc.cache.Store(ingress)
+	svc.update()
+}
+
+// Health of data sources. Watchers should report state of sources to it.
+func (svc *Service) Health() *Health {
+	return svc.health
 }
 
 func (svc *Service) Get() []Ingress {
@@ -143,6 +384,13 @@ func (svc *Service) Get() []Ingress {
 // Prepend static list of ingresses.
 func (svc *Service) Prepend(ingress []Ingress) {
 	svc.prepend.Store(ingress)
+	svc.update()
+}
+
+// SetCustom sets list of ingresses defined by custom resources. They are placed after static list.
+func (svc *Service) SetCustom(ingress []Ingress) {
+	svc.custom.Store(ingress)
+	svc.update()
 }
 
 func (svc *Service) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
@@ -150,29 +398,67 @@ func (svc *Service) ServeHTTP(writer http.ResponseWriter, request *http.Request)
 }
 
 func (svc *Service) getList() []Ingress {
-	prepend, ok := svc.prepend.Load().([]Ingress)
-	if !ok {
-		return nil
-	}
-	main, ok := svc.cache.Load().([]Ingress)
-	if !ok {
-		return prepend
-	}
+	prepend, _ := svc.prepend.Load().([]Ingress)
+	custom, _ := svc.custom.Load().([]Ingress)
+	main, _ := svc.cache.Load().([]Ingress)
+
+	list := make([]Ingress, 0, len(prepend)+len(custom)+len(main))
+	list = append(list, prepend...)
+	list = append(list, custom...)
 
-	return append(prepend, main...)
+	return append(list, main...)
 }
 
 func (svc *Service) getIndex(writer http.ResponseWriter, request *http.Request, _ httprouter.Params) {
-	writer.Header().Set("Content-Type", "text/html")
-	if err := svc.page.Execute(writer, UIContext{
-		Ingresses: visibleIngresses(svc.getList()),
+	svc.renderIndex(writer, request, ParseFilter(request.URL.Query()))
+}
+
+func (svc *Service) renderIndex(writer http.ResponseWriter, request *http.Request, filter Filter) {
+	locale := svc.locale(writer, request)
+	list := locale.localize(visibleIngresses(svc.getList()))
+	column, desc := parseSortColumn(request.URL.Query().Get(sortParam))
+	sortValue := sortColumnValue(column, desc)
+	found := sortByColumn(filter.Search(list), column, desc)
+	group := parseGroup(request.URL.Query())
+	mode := layout(writer, request)
+	var groups []UIGroup
+	if group != "" {
+		groups = groupIngresses(found, group)
+	}
+	ui := UIContext{
+		Ingresses: found,
 		User:      auth.UserFromContext(request.Context()),
-	}); err != nil {
+		Loading:   svc.health.Loading(),
+		Problems:  countProblems(list),
+		Search: keepView(UISearch{
+			Query:    filter.Query,
+			Params:   hiddenParams(filter),
+			Facets:   facets(list, filter),
+			Filtered: !filter.IsEmpty(),
+			Total:    len(list),
+		}, viewParams(group, sortValue, mode)),
+		Group:    group,
+		Groups:   groups,
+		Views:    viewModes(filter, group, sortValue),
+		Layout:   mode,
+		Layouts:  layoutModes(filter, group, mode, sortValue),
+		Columns:  tableColumns(filter, group, column, desc),
+		Branding: svc.branding,
+		Locale:   locale,
+		Locales:  svc.locales.list,
+	}.withFavourites(list, svc.favouritesOf(request))
+	if !filter.IsEmpty() {
+		ui.Favourites = nil // search results already shown
+	}
+	writer.Header().Set("Content-Type", "text/html")
+	if err := svc.page.Execute(writer, locale.translate(ui)); err != nil {
 		log.Println("failed render details page:", err)
 	}
 }
+
 func (svc *Service) getDetails(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
-	list := visibleIngresses(svc.getList())
+	locale := svc.locale(writer, request)
+	list := locale.localize(visibleIngresses(svc.getList()))
 	var ingress Ingress
 	var found bool
 	uid := params.ByName("uid")
@@ -180,9 +466,6 @@ func (svc *Service) getDetails(writer http.ResponseWriter, request *http.Request
 		if ing.UID != uid {
 			continue
 		}
-		if ing.Static {
-			break // disable details for static members
-		}
 		found = true
 		ingress = ing
 
@@ -196,9 +479,7 @@ func (svc *Service) getDetails(writer http.ResponseWriter, request *http.Request
 
 	var byNamespaces = make(map[string][]Ingress, len(list))
 	for _, item := range list {
-		if !item.Static {
-			byNamespaces[item.Namespace] = append(byNamespaces[item.Namespace], item)
-		}
+		byNamespaces[item.Namespace] = append(byNamespaces[item.Namespace], item)
 	}
 
 	var namespaces = make([]string, 0, len(byNamespaces))
@@ -212,7 +493,11 @@ func (svc *Service) getDetails(writer http.ResponseWriter, request *http.Request
 		UIContext: UIContext{
 			Ingresses: list,
 			User:      auth.UserFromContext(request.Context()),
-		},
+			Problems:  countProblems(list),
+			Branding:  svc.branding,
+			Locale:    locale,
+			Locales:   svc.locales.list,
+		}.withFavourites(list, svc.favouritesOf(request)),
 		Ingress:     ingress,
 		ByNamespace: byNamespaces,
 		Namespaces:  namespaces,
@@ -221,6 +506,14 @@ func (svc *Service) getDetails(writer http.ResponseWriter, request *http.Request
 	}
 }
 
+func (svc *Service) getExport(writer http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	writer.Header().Set("Content-Type", "application/yaml")
+	writer.Header().Set("Content-Disposition", "attachment; filename=\"static.yaml\"")
+	if err := WriteDefinitions(writer, visibleIngresses(svc.getList())); err != nil {
+		log.Println("failed export definitions:", err)
+	}
+}
+
 func visibleIngresses(list []Ingress) []Ingress {
 	clone := make([]Ingress, 0, len(list))
 	for _, ing := range list {
@@ -235,17 +528,19 @@ func visibleIngresses(list []Ingress) []Ingress {
 // LoadDefinitions scans location (file or dir) for YAML/JSON (.yml, .yaml, .json) definitions of Ingress.
 // Directories scanned recursive and each file can contain multiple definitions.
 //
+// Each definition gets stable UID generated from namespace and name. Unless tls is set explicitly,
+// TLS is enabled automatically if at least one URL uses HTTPS.
+//
+// Before decoding, placeholders ${NAME} and ${NAME:-default} are replaced by values from vars
+// or from environment variables. Undefined variables without default value cause an error.
+//
 // Empty location is a special case and cause returning empty slice.
-func LoadDefinitions(location string) ([]Ingress, error) {
-	type YamlIngress struct {
-		Ingress `yaml:",inline"`
-		URLs    []string `yaml:"urls"`
-	}
-
+func LoadDefinitions(location string, vars map[string]string) ([]Ingress, error) {
 	if location == "" {
 		return nil, nil
 	}
 	var ans []Ingress
+	var usedUIDs = make(map[string]int)
 	err := filepath.Walk(location, func(path string, info fs.FileInfo, err error) error {
 		if info.IsDir() {
 			return nil
@@ -258,15 +553,19 @@ func LoadDefinitions(location string) ([]Ingress, error) {
 			return nil
 		}
 
-		configFile, err := os.Open(path)
+		content, err := os.ReadFile(path)
 		if err != nil {
-			return fmt.Errorf("open config file: %w", err)
+			return fmt.Errorf("read config file: %w", err)
 		}
-		defer configFile.Close()
 
-		var decoder = yaml.NewDecoder(configFile)
+		content, err = expandVariables(content, vars)
+		if err != nil {
+			return fmt.Errorf("expand variables in %s: %w", path, err)
+		}
+
+		var decoder = yaml.NewDecoder(bytes.NewReader(content))
 		for {
-			var ingress YamlIngress
+			var ingress yamlIngress
 			ingress.Static = true
 			err := decoder.Decode(&ingress)
 			if errors.Is(err, io.EOF) {
@@ -282,6 +581,19 @@ func LoadDefinitions(location string) ([]Ingress, error) {
 					Static: true,
 				})
 			}
+			ingress.Ingress.TLS = detectTLS(ingress.URLs)
+			if ingress.TLS != nil {
+				ingress.Ingress.TLS = *ingress.TLS
+			}
+
+			ingress.ID = ingress.Namespace + "." + ingress.Name
+			ingress.UID = staticUID(ingress.Namespace, ingress.Name)
+			if n := usedUIDs[ingress.UID]; n > 0 {
+				usedUIDs[ingress.UID]++
+				ingress.UID += "-" + strconv.Itoa(n)
+			} else {
+				usedUIDs[ingress.UID] = 1
+			}
 
 			ans = append(ans, ingress.Ingress)
 		}
@@ -291,3 +603,82 @@ func LoadDefinitions(location string) ([]Ingress, error) {
 
 	return ans, err
 }
+
+// WriteDefinitions writes ingresses as multi-document YAML in the same format as used by LoadDefinitions.
+// Placeholders of variables in values are escaped, so definitions are loaded exactly as they were written.
+// Discovered logos are not written: they will be discovered again.
+func WriteDefinitions(writer io.Writer, ingresses []Ingress) error {
+	encoder := yaml.NewEncoder(writer)
+	encoder.SetIndent(2) //nolint:gomnd
+	for _, ing := range ingresses {
+		if ing.LogoFound {
+			ing.LogoURL = ""
+		}
+		item := yamlIngress{Ingress: escapeVariables(ing)}
+		for _, ref := range ing.Refs {
+			item.URLs = append(item.URLs, escapeVariable(ref.URL))
+		}
+		if tls := ing.TLS; tls != detectTLS(item.URLs) {
+			item.TLS = &tls
+		}
+		if err := encoder.Encode(item); err != nil {
+			return fmt.Errorf("encode %s: %w", ing.ID, err)
+		}
+	}
+
+	return encoder.Close()
+}
+
+// yamlIngress is representation of static definition.
+type yamlIngress struct {
+	Ingress `yaml:",inline"`
+	URLs    []string `yaml:"urls"`
+	TLS     *bool    `yaml:"tls,omitempty"` // explicit TLS mode, detected by URLs if not set
+}
+
+// escapeVariables in all user-defined text fields of ingress. Slices and maps are copied.
+func escapeVariables(ingress Ingress) Ingress {
+	ingress.Title = escapeVariable(ingress.Title)
+	ingress.Name = escapeVariable(ingress.Name)
+	ingress.Namespace = escapeVariable(ingress.Namespace)
+	ingress.Description = escapeVariable(ingress.Description)
+	ingress.LogoURL = escapeVariable(ingress.LogoURL)
+	ingress.Alias = escapeVariable(ingress.Alias)
+	var tags []string
+	for _, tag := range ingress.Tags {
+		tags = append(tags, escapeVariable(tag))
+	}
+	ingress.Tags = tags
+	if ingress.Descriptions != nil {
+		var descriptions = make(map[string]string, len(ingress.Descriptions))
+		for lang, description := range ingress.Descriptions {
+			descriptions[lang] = escapeVariable(description)
+		}
+		ingress.Descriptions = descriptions
+	}
+
+	return ingress
+}
+
+// escapeVariable placeholders: ${NAME} becomes $${NAME}, so it's not expanded by LoadDefinitions.
+func escapeVariable(value string) string {
+	return strings.ReplaceAll(value, "${", "$${")
+}
+
+// detectTLS is true if at least one URL uses HTTPS.
+func detectTLS(urls []string) bool {
+	for _, u := range urls {
+		if strings.HasPrefix(u, "https://") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// staticUID generates stable UID for static definition based on namespace and name.
+func staticUID(namespace, name string) string {
+	hash := sha256.Sum256([]byte(namespace + "/" + name))
+
+	return "static-" + hex.EncodeToString(hash[:staticUIDSize])
+}
@@ -2,6 +2,8 @@ package internal_test
 
 import (
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
@@ -33,26 +35,177 @@ urls:
 `), 0755)
 	require.NoError(t, err)
 
-	list, err := internal.LoadDefinitions(tempDir)
+	list, err := internal.LoadDefinitions(tempDir, nil)
 	require.NoError(t, err)
 	require.Len(t, list, 2)
 
+	require.NotEmpty(t, list[0].UID)
+	require.NotEmpty(t, list[1].UID)
+	require.NotEqual(t, list[0].UID, list[1].UID)
+
 	require.Equal(t, internal.Ingress{
+		ID:        "external links.Some site",
+		UID:       list[0].UID,
 		Name:      "Some site",
 		Namespace: "external links",
+		Static:    true,
+		TLS:       true,
 		Refs: []internal.Ref{
 			{URL: "https://example.com", Static: true},
 		},
 	}, list[0])
 
 	require.Equal(t, internal.Ingress{
+		ID:          "external links.Google",
+		UID:         list[1].UID,
 		Name:        "Google",
 		Namespace:   "external links",
 		Description: "Well-known search engine\n",
 		LogoURL:     "https://www.google.ru/favicon.ico",
+		Static:      true,
+		TLS:         true,
 		Refs: []internal.Ref{
 			{URL: "https://google.com", Static: true},
 			{URL: "http://example.com", Static: true},
 		},
 	}, list[1])
 }
+
+func TestLoadDefinitions_presentation(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	err = ioutil.WriteFile(filepath.Join(tempDir, "demo.yaml"), []byte(`
+---
+name: site
+title: Some Site
+hide: true
+tls: true
+tags: [docs, external]
+urls:
+  - http://example.com
+---
+name: site
+urls:
+  - http://example.com
+---
+name: insecure
+tls: false
+urls:
+  - https://example.com
+`), 0755)
+	require.NoError(t, err)
+
+	list, err := internal.LoadDefinitions(tempDir, nil)
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+
+	require.Equal(t, "Some Site", list[0].Title)
+	require.True(t, list[0].Hide)
+	require.True(t, list[0].TLS)
+	require.Equal(t, []string{"docs", "external"}, list[0].Tags)
+	require.False(t, list[1].TLS)
+	require.False(t, list[2].TLS, "explicit tls should override detection by URLs")
+	require.NotEqual(t, list[0].UID, list[1].UID, "UID should be unique even for duplicated names")
+
+	again, err := internal.LoadDefinitions(tempDir, nil)
+	require.NoError(t, err)
+	require.Equal(t, list[0].UID, again[0].UID, "UID should be stable")
+	require.Equal(t, list[1].UID, again[1].UID, "UID should be stable")
+}
+
+func TestLoadDefinitions_variables(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	err = ioutil.WriteFile(filepath.Join(tempDir, "demo.yaml"), []byte(`
+---
+name: Grafana
+namespace: ${CLUSTER:-dev}
+urls:
+  - https://grafana.${DOMAIN}
+`), 0755)
+	require.NoError(t, err)
+
+	list, err := internal.LoadDefinitions(tempDir, map[string]string{"DOMAIN": "example.com"})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, "dev", list[0].Namespace)
+	require.Equal(t, "https://grafana.example.com", list[0].Refs[0].URL)
+
+	_, err = internal.LoadDefinitions(tempDir, nil)
+	require.Error(t, err)
+}
+
+func TestExport_roundTrip(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	svc := internal.New()
+	svc.Prepend([]internal.Ingress{{
+		ID:          "external.site",
+		UID:         "static",
+		Name:        "site",
+		Namespace:   "external",
+		Title:       "Some ${SITE}",
+		Description: "Static site, costs $${PRICE}",
+		LogoURL:     "http://example.com/favicon.ico",
+		LogoFound:   true,
+		Tags:        []string{"docs"},
+		Static:      true,
+		Refs:        []internal.Ref{{URL: "http://example.com/?q=${query}", Static: true}},
+	}})
+	svc.Set([]internal.Ingress{{
+		ID:        "default.app",
+		UID:       "1234",
+		Name:      "app",
+		Namespace: "default",
+		Class:     "nginx",
+		LogoURL:   "/favicon.ico",
+		TLS:       true,
+		Refs:      []internal.Ref{{URL: "https://app.example.com/", Pods: 2}},
+	}, {
+		ID:        "default.hidden",
+		UID:       "5678",
+		Name:      "hidden",
+		Namespace: "default",
+		Hide:      true,
+	}})
+
+	res := httptest.NewRecorder()
+	svc.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/export.yaml", nil))
+	require.Equal(t, http.StatusOK, res.Code)
+
+	err = ioutil.WriteFile(filepath.Join(tempDir, "export.yaml"), res.Body.Bytes(), 0600)
+	require.NoError(t, err)
+
+	list, err := internal.LoadDefinitions(tempDir, nil)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+
+	require.Equal(t, internal.Ingress{
+		ID:          "external.site",
+		UID:         list[0].UID,
+		Name:        "site",
+		Namespace:   "external",
+		Title:       "Some ${SITE}",
+		Description: "Static site, costs $${PRICE}",
+		Tags:        []string{"docs"},
+		Static:      true,
+		Refs:        []internal.Ref{{URL: "http://example.com/?q=${query}", Static: true}},
+	}, list[0])
+
+	require.Equal(t, internal.Ingress{
+		ID:        "default.app",
+		UID:       list[1].UID,
+		Name:      "app",
+		Namespace: "default",
+		LogoURL:   "/favicon.ico",
+		TLS:       true,
+		Static:    true,
+		Refs:      []internal.Ref{{URL: "https://app.example.com/", Static: true}},
+	}, list[1])
+}
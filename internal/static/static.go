@@ -2,7 +2,10 @@ package static
 
 import (
 	"embed"
+	"errors"
+	"fmt"
 	"io/fs"
+	"os"
 )
 
 // Templates used for rendering UI.
@@ -13,6 +16,14 @@ var Templates embed.FS
 //go:embed assets/static/**
 var Files embed.FS
 
+// Locales are message catalogs of UI (<language>.yaml).
+//go:embed assets/locales/*.yaml
+var Locales embed.FS
+
+// OpenAPI description of JSON API.
+//go:embed assets/api/openapi.json
+var OpenAPI []byte
+
 func Static() fs.FS {
 	f, err := fs.Sub(Files, "assets/static")
 	if err != nil {
@@ -21,3 +32,57 @@ func Static() fs.FS {
 
 	return f
 }
+
+// TemplatesFS is Templates with file names without prefix (index.gotemplate, ...).
+func TemplatesFS() fs.FS {
+	f, err := fs.Sub(Templates, "assets/templates")
+	if err != nil {
+		panic(err) // this should never happen
+	}
+
+	return f
+}
+
+// LocalesFS is Locales with file names without prefix (en.yaml, ...).
+func LocalesFS() fs.FS {
+	f, err := fs.Sub(Locales, "assets/locales")
+	if err != nil {
+		panic(err) // this should never happen
+	}
+
+	return f
+}
+
+// Overlay returns file system where files from directory take precedence over files with the same name in base.
+// Empty directory means base only.
+func Overlay(dir string, base fs.FS) (fs.FS, error) {
+	if dir == "" {
+		return base, nil
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("overlay directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("overlay %s is not a directory", dir)
+	}
+
+	return &overlayFS{top: os.DirFS(dir), base: base}, nil
+}
+
+type overlayFS struct {
+	top  fs.FS
+	base fs.FS
+}
+
+func (ofs *overlayFS) Open(name string) (fs.File, error) {
+	f, err := ofs.top.Open(name)
+	if err == nil {
+		return f, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	return ofs.base.Open(name)
+}
@@ -0,0 +1,37 @@
+package internal
+
+import (
+	"context"
+	"sync"
+)
+
+// WatchStatic discovers logos and TLS certificates info for static definitions and pushes
+// updated list to the receiver. Order of definitions is preserved. Blocks till context canceled.
+func WatchStatic(global context.Context, ingresses []Ingress, receiver Receiver) {
+	ctx, cancel := context.WithCancel(global)
+	defer cancel()
+
+	watcher := newEnricher(receiver)
+	for _, ing := range ingresses {
+		watcher.cache[ing.UID] = ing
+		watcher.order = append(watcher.order, ing.UID)
+	}
+	watcher.notify()
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer cancel()
+		watcher.runLogoFetcher(ctx)
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer cancel()
+		watcher.runCertsInfoCheck(ctx)
+	}()
+	wg.Wait()
+}
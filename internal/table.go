@@ -0,0 +1,218 @@
+package internal
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Layouts of index page.
+const (
+	LayoutCards = "cards"
+	LayoutTable = "table"
+)
+
+const (
+	layoutParam     = "view" // query parameter and cookie with selected layout
+	layoutCookieAge = 365 * 24 * time.Hour
+	descPrefix      = "-" // prefix of sort column for descending order
+)
+
+// Sortable columns of table.
+const (
+	ColumnName      = "name"
+	ColumnNamespace = "namespace"
+	ColumnHosts     = "hosts"
+	ColumnClass     = "class"
+	ColumnReady     = "ready"
+	ColumnExpiry    = "expiry"
+)
+
+// UIColumn is header of table with link to sort by the column.
+type UIColumn struct {
+	Key    string
+	Label  string
+	Active bool // entries sorted by the column
+	Desc   bool // descending order, only for active column
+	URL    string
+}
+
+// layout of index page: explicitly selected by query parameter (remembered in cookie), from cookie or cards.
+func layout(writer http.ResponseWriter, request *http.Request) string {
+	switch value := request.URL.Query().Get(layoutParam); value {
+	case LayoutCards, LayoutTable:
+		http.SetCookie(writer, &http.Cookie{
+			Name:     layoutParam,
+			Value:    value,
+			Path:     "/",
+			MaxAge:   int(layoutCookieAge / time.Second),
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		})
+
+		return value
+	}
+	if cookie, err := request.Cookie(layoutParam); err == nil && cookie.Value == LayoutTable {
+		return LayoutTable
+	}
+
+	return LayoutCards
+}
+
+// parseSortColumn from sort=<column> or sort=-<column>. Unknown column means original order (relevance).
+func parseSortColumn(value string) (column string, desc bool) {
+	desc = strings.HasPrefix(value, descPrefix)
+	column = strings.TrimPrefix(value, descPrefix)
+	switch column {
+	case ColumnName, ColumnNamespace, ColumnHosts, ColumnClass, ColumnReady, ColumnExpiry:
+		return column, desc
+	default:
+		return "", false
+	}
+}
+
+// sortColumnValue is value of sort parameter, opposite to parseSortColumn.
+func sortColumnValue(column string, desc bool) string {
+	if column != "" && desc {
+		return descPrefix + column
+	}
+
+	return column
+}
+
+// sortByColumn returns sorted copy of list. Entries without value (no hosts, no class, unknown expiration)
+// are always placed after others.
+func sortByColumn(list []Ingress, column string, desc bool) []Ingress {
+	if column == "" {
+		return list
+	}
+	var cp = make([]Ingress, len(list))
+	copy(cp, list)
+
+	compare := func(a, b Ingress) (less, equal bool) {
+		switch column {
+		case ColumnNamespace:
+			return compareStrings(a.Namespace, b.Namespace, desc)
+		case ColumnHosts:
+			return compareStrings(firstString(a.Hosts()), firstString(b.Hosts()), desc)
+		case ColumnClass:
+			return compareStrings(a.Class, b.Class, desc)
+		case ColumnReady:
+			return compareInts(readiness(a), readiness(b), desc)
+		case ColumnExpiry:
+			return compareTimes(a.Cert.Expiration, b.Cert.Expiration, desc)
+		default:
+			return compareStrings(strings.ToLower(a.Label()), strings.ToLower(b.Label()), desc)
+		}
+	}
+	sort.SliceStable(cp, func(i, j int) bool {
+		if less, equal := compare(cp[i], cp[j]); !equal {
+			return less
+		}
+
+		return strings.ToLower(cp[i].Label()) < strings.ToLower(cp[j].Label())
+	})
+
+	return cp
+}
+
+// readiness is number of hosts or -1 for static entries (hosts are not known).
+func readiness(ingress Ingress) int {
+	if ingress.Static {
+		return -1
+	}
+
+	return ingress.Pods()
+}
+
+func compareStrings(a, b string, desc bool) (less, equal bool) {
+	switch {
+	case a == b:
+		return false, true
+	case a == "" || b == "":
+		return b == "", false // empty values are the last
+	case desc:
+		return a > b, false
+	default:
+		return a < b, false
+	}
+}
+
+func compareInts(a, b int, desc bool) (less, equal bool) {
+	switch {
+	case a == b:
+		return false, true
+	case a < 0 || b < 0:
+		return b < 0, false // unknown values are the last
+	case desc:
+		return a > b, false
+	default:
+		return a < b, false
+	}
+}
+
+func compareTimes(a, b time.Time, desc bool) (less, equal bool) {
+	switch {
+	case a.Equal(b):
+		return false, true
+	case a.IsZero() || b.IsZero():
+		return b.IsZero(), false // unknown values are the last
+	case desc:
+		return a.After(b), false
+	default:
+		return a.Before(b), false
+	}
+}
+
+func firstString(list []string) string {
+	if len(list) == 0 {
+		return ""
+	}
+
+	return list[0]
+}
+
+// tableColumns with links to sort by them. Click on active column toggles order.
+func tableColumns(filter Filter, group, active string, desc bool) []UIColumn {
+	var ans []UIColumn
+	for _, column := range []string{ColumnName, ColumnNamespace, ColumnHosts, ColumnClass, ColumnReady, ColumnExpiry} {
+		values := filter.Values()
+		if group != "" {
+			values.Set(groupParam, group)
+		}
+		values.Set(layoutParam, LayoutTable)
+		sortValue := column
+		if column == active && !desc {
+			sortValue = descPrefix + column
+		}
+		values.Set(sortParam, sortValue)
+		ans = append(ans, UIColumn{
+			Key:    column,
+			Label:  column,
+			Active: column == active,
+			Desc:   column == active && desc,
+			URL:    "./?" + values.Encode(),
+		})
+	}
+
+	return ans
+}
+
+// layoutModes are links to the same page with different layout.
+func layoutModes(filter Filter, group, active, sortValue string) []ViewMode {
+	var ans []ViewMode
+	for _, mode := range []string{LayoutCards, LayoutTable} {
+		values := filter.Values()
+		if group != "" {
+			values.Set(groupParam, group)
+		}
+		if sortValue != "" {
+			values.Set(sortParam, sortValue)
+		}
+		values.Set(layoutParam, mode)
+		ans = append(ans, ViewMode{Label: mode, Mode: mode, Active: mode == active, URL: "./?" + values.Encode()})
+	}
+
+	return ans
+}
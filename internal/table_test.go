@@ -0,0 +1,112 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortByColumn(t *testing.T) {
+	list := []Ingress{
+		{UID: "docs", Name: "docs", Namespace: "external", Static: true},
+		{UID: "grafana-dev", Name: "grafana-dev", Namespace: "dev", Class: "nginx", Refs: []Ref{{URL: "http://grafana.dev.example.com", Pods: 1}}},
+		{UID: "grafana", Name: "grafana", Namespace: "monitoring", Class: "nginx", Refs: []Ref{{URL: "https://grafana.example.com", Pods: 1}}},
+		{UID: "prometheus", Name: "prometheus", Namespace: "monitoring", Class: "traefik", Refs: []Ref{{URL: "http://prometheus.example.com"}}},
+	}
+
+	t.Run("no column keeps order", func(t *testing.T) {
+		require.Equal(t, uids(list), uids(sortByColumn(list, "", false)))
+	})
+
+	t.Run("by name", func(t *testing.T) {
+		require.Equal(t, []string{"docs", "grafana", "grafana-dev", "prometheus"}, uids(sortByColumn(list, ColumnName, false)))
+	})
+
+	t.Run("by namespace descending", func(t *testing.T) {
+		require.Equal(t, []string{"grafana", "prometheus", "docs", "grafana-dev"}, uids(sortByColumn(list, ColumnNamespace, true)))
+	})
+
+	t.Run("empty values are last", func(t *testing.T) {
+		require.Equal(t, []string{"grafana-dev", "grafana", "prometheus", "docs"}, uids(sortByColumn(list, ColumnHosts, false)))
+		require.Equal(t, []string{"grafana", "grafana-dev", "prometheus", "docs"}, uids(sortByColumn(list, ColumnClass, false)))
+		require.Equal(t, []string{"grafana", "grafana-dev", "prometheus", "docs"}, uids(sortByColumn(list, ColumnReady, true)))
+	})
+
+	t.Run("original list is not changed", func(t *testing.T) {
+		require.Equal(t, []string{"docs", "grafana-dev", "grafana", "prometheus"}, uids(list))
+	})
+}
+
+func TestParseSortColumn(t *testing.T) {
+	column, desc := parseSortColumn("-expiry")
+	require.Equal(t, ColumnExpiry, column)
+	require.True(t, desc)
+
+	column, desc = parseSortColumn("namespace")
+	require.Equal(t, ColumnNamespace, column)
+	require.False(t, desc)
+
+	column, _ = parseSortColumn("severity")
+	require.Empty(t, column)
+}
+
+func TestService_tableView(t *testing.T) {
+	svc := New()
+	svc.Set([]Ingress{{UID: "grafana", Name: "grafana", Namespace: "monitoring", Refs: []Ref{{URL: "https://grafana.example.com"}}}})
+
+	get := func(target string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		res := httptest.NewRecorder()
+		svc.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code)
+
+		return res
+	}
+
+	res := get("/?view=table&sort=name")
+	cookies := res.Result().Cookies()
+	require.Len(t, cookies, 1)
+	require.Equal(t, layoutParam, cookies[0].Name)
+	require.Equal(t, LayoutTable, cookies[0].Value)
+	body := res.Body.String()
+	require.Contains(t, body, `<table class="entries">`)
+	require.NotContains(t, body, `<div class="card-holder">`)
+	require.Contains(t, body, `<a href="./?sort=-name&amp;view=table">name</a> ▲`)
+	require.Contains(t, body, `<a href="./?sort=namespace&amp;view=table">namespace</a></th>`)
+	require.Contains(t, body, `href="./?sort=name&amp;view=cards"`, "sort should be kept by layout")
+	require.Contains(t, body, `href="./?group=namespace&amp;sort=name"`, "sort should be kept by grouping")
+
+	t.Run("remembered in cookie", func(t *testing.T) {
+		body := get("/", cookies[0]).Body.String()
+		require.Contains(t, body, `<table class="entries">`)
+	})
+
+	t.Run("switch back to cards", func(t *testing.T) {
+		res := get("/?view=cards", cookies[0])
+		require.Equal(t, LayoutCards, res.Result().Cookies()[0].Value)
+		require.Contains(t, res.Body.String(), `<div class="card-holder">`)
+		require.NotContains(t, res.Body.String(), `<table class="entries">`)
+	})
+}
+
+func TestService_searchKeepsView(t *testing.T) {
+	svc := New()
+	svc.Set([]Ingress{{UID: "grafana", Name: "grafana", Namespace: "monitoring", Refs: []Ref{{URL: "https://grafana.example.com"}}}})
+
+	req := httptest.NewRequest(http.MethodGet, "/?view=table&sort=-expiry&group=namespace&namespace=monitoring", nil)
+	res := httptest.NewRecorder()
+	svc.ServeHTTP(res, req)
+	require.Equal(t, http.StatusOK, res.Code)
+	body := res.Body.String()
+
+	require.Contains(t, body, `<input type="hidden" name="group" value="namespace">`)
+	require.Contains(t, body, `<input type="hidden" name="sort" value="-expiry">`)
+	require.Contains(t, body, `<input type="hidden" name="view" value="table">`)
+	require.Contains(t, body, `href="./?group=namespace&amp;sort=-expiry&amp;view=table"`, "facet and reset links should keep view")
+	require.NotContains(t, body, `href="./?namespace=monitoring"`, "facet links should not drop view")
+}
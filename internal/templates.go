@@ -0,0 +1,223 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"strings"
+	textTemplate "text/template"
+	"time"
+
+	"github.com/hako/durafmt"
+	"github.com/reddec/ingress-dashboard/internal/auth"
+)
+
+// Names of templates. Files with the same names in templates directory override embedded templates.
+const (
+	templateIndex     = "index.gotemplate"
+	templateDetails   = "details.gotemplate"
+	templateAliases   = "aliases.gotemplate"
+	templateNamespace = "namespace.gotemplate"
+	templateProblems  = "problems.gotemplate"
+	templateWall      = "wall.gotemplate"
+	templateBookmarks = "bookmarks.gotemplate"
+)
+
+var errDictArgs = errors.New("dict expects even number of arguments: key, value, ...")
+
+// templateFuncs are available in all templates in addition to standard functions.
+func templateFuncs() map[string]interface{} {
+	return map[string]interface{}{
+		"lower":     strings.ToLower,
+		"upper":     strings.ToUpper,
+		"trim":      strings.TrimSpace,
+		"join":      func(sep string, list []string) string { return strings.Join(list, sep) },
+		"split":     func(sep, value string) []string { return strings.Split(value, sep) },
+		"contains":  func(sub, value string) bool { return strings.Contains(value, sub) },
+		"hasPrefix": func(prefix, value string) bool { return strings.HasPrefix(value, prefix) },
+		"hasSuffix": func(suffix, value string) bool { return strings.HasSuffix(value, suffix) },
+		"replace":   func(old, replacement, value string) string { return strings.ReplaceAll(value, old, replacement) },
+		"default": func(fallback, value interface{}) interface{} {
+			if value == nil || value == "" {
+				return fallback
+			}
+
+			return value
+		},
+		"now":        time.Now,
+		"formatTime": func(layout string, t time.Time) string { return t.Format(layout) },
+		"until":      func(t time.Time) string { return durafmt.Parse(time.Until(t)).LimitFirstN(2).String() }, //nolint:gomnd
+		"dict": func(pairs ...interface{}) (map[string]interface{}, error) {
+			if len(pairs)%2 != 0 {
+				return nil, errDictArgs
+			}
+			var ans = make(map[string]interface{}, len(pairs)/2) //nolint:gomnd
+			for i := 0; i < len(pairs); i += 2 {
+				ans[fmt.Sprint(pairs[i])] = pairs[i+1]
+			}
+
+			return ans, nil
+		},
+	}
+}
+
+func parseHTMLTemplate(fsys fs.FS, name string) (*template.Template, error) {
+	t, err := template.New(name).Funcs(templateFuncs()).ParseFS(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("parse template %s: %w", name, err)
+	}
+
+	return t, nil
+}
+
+func parseTextTemplate(fsys fs.FS, name string) (*textTemplate.Template, error) {
+	t, err := textTemplate.New(name).Funcs(templateFuncs()).ParseFS(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("parse template %s: %w", name, err)
+	}
+
+	return t, nil
+}
+
+// validateTemplates renders all templates with sample data. It catches errors which are not detected
+// during parsing: unknown fields, wrong functions arguments and HTML contexts.
+func (svc *Service) validateTemplates() error {
+	sample := sampleIngresses()
+	ui := UIContext{
+		Ingresses: sample,
+		User:      &auth.User{Name: "Sample"},
+		Search: UISearch{
+			Query:    "sample",
+			Params:   []QueryParam{{Name: facetNamespace, Value: "default"}},
+			Facets:   facets(sample, Filter{}),
+			Filtered: true,
+			Total:    len(sample),
+			ResetURL: "./",
+		},
+		Problems: 1,
+		Locale:   svc.locales.fallback,
+		Locales:  svc.locales.list,
+		Group:    GroupNamespace,
+		Groups:   groupIngresses(sample, GroupNamespace),
+		Views:    viewModes(Filter{}, GroupNamespace, ""),
+		Layout:   LayoutTable,
+		Layouts:  layoutModes(Filter{}, GroupNamespace, LayoutTable, ""),
+		Columns:  tableColumns(Filter{}, GroupNamespace, ColumnExpiry, true),
+		Branding: Branding{
+			Title:       "Sample",
+			LogoURL:     "/logo.png",
+			AccentColor: "#118bee",
+			Theme:       ThemeDark,
+			CustomCSS:   "/custom.css",
+			Links:       []BrandLink{{Title: "Sample", URL: "https://example.com"}},
+		},
+	}.withFavourites(sample, []string{sample[0].ID})
+	links := resolveGoLinks(sample)
+
+	var checks = []struct {
+		name    string
+		execute func(io.Writer) error
+	}{
+		{templateIndex, func(w io.Writer) error { return svc.page.Execute(w, ui) }},
+		{templateIndex, func(w io.Writer) error {
+			cards := ui
+			cards.Layout = LayoutCards
+
+			return svc.page.Execute(w, cards)
+		}},
+		{templateDetails, func(w io.Writer) error {
+			return svc.details.Execute(w, UIDetailsContext{
+				UIContext:   ui,
+				Ingress:     sample[0],
+				ByNamespace: map[string][]Ingress{"default": sample},
+				Namespaces:  []string{"default"},
+			})
+		}},
+		{templateAliases, func(w io.Writer) error {
+			return svc.aliases.Execute(w, UIAliasesContext{
+				UIContext: ui,
+				Links:     links.sorted(),
+				Conflicts: []GoLinkConflict{{Alias: "sample", Explicit: true, Ingresses: sample}},
+				Invalid:   sample[:1],
+			})
+		}},
+		{templateNamespace, func(w io.Writer) error {
+			return svc.namespace.Execute(w, UINamespaceContext{
+				UIContext: ui,
+				Namespace: Namespace{
+					Name:        "default",
+					Title:       "Default",
+					Description: "Sample namespace",
+					Labels:      map[string]string{"team": "sample"},
+					Created:     time.Now(),
+				},
+				Health: namespaceHealth(sample),
+			})
+		}},
+		{templateProblems, func(w io.Writer) error {
+			return svc.problems.Execute(w, UIProblemsContext{
+				UIContext: ui,
+				Entries:   findProblems(sample, SortSeverity),
+				Sort:      SortSeverity,
+				Sorts:     sortModes(SortSeverity),
+			})
+		}},
+		{templateWall, func(w io.Writer) error {
+			return svc.wall.Execute(w, UIWallContext{
+				UIContext: ui,
+				Tiles:     wallTiles(sample),
+				Health:    namespaceHealth(sample),
+				Refresh:   defaultRefresh,
+			})
+		}},
+		{templateBookmarks, func(w io.Writer) error {
+			return svc.bookmarks.Execute(w, bookmarksFile{
+				Title:     defaultTitle,
+				Folders:   []bookmarksFolder{{Name: "default", Bookmarks: toBookmarks(sample[0], "")}},
+				Bookmarks: toBookmarks(sample[1], ""),
+			})
+		}},
+	}
+	for _, check := range checks {
+		if err := check.execute(io.Discard); err != nil {
+			return fmt.Errorf("render template %s: %w", check.name, err)
+		}
+	}
+
+	return nil
+}
+
+func sampleIngresses() []Ingress {
+	return []Ingress{
+		{
+			ID:          "default.sample",
+			UID:         "sample",
+			Title:       "Sample",
+			Name:        "sample",
+			Namespace:   "default",
+			Description: "Sample entry",
+			LogoURL:     "/logo.png",
+			Class:       "nginx",
+			Tags:        []string{"sample"},
+			Alias:       "sample",
+			Refs:        []Ref{{URL: "https://sample.example.com", Pods: 1}},
+			TLS:         true,
+			Cert: CertInfo{
+				Host:       "sample.example.com",
+				Expiration: time.Now().Add(SoonExpiredInterval / 2), //nolint:gomnd
+				Domains:    []string{"sample.example.com"},
+				Issuer:     "Sample CA",
+			},
+		},
+		{
+			ID:        "static",
+			UID:       "static",
+			Name:      "static",
+			Static:    true,
+			Refs:      []Ref{{URL: "http://static.example.com", Static: true}},
+			LogoError: "no logo in meta",
+		},
+	}
+}
@@ -0,0 +1,67 @@
+package internal
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	writeFile := func(t *testing.T, dir, name, content string) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+	get := func(svc *Service, path string) string {
+		res := httptest.NewRecorder()
+		svc.ServeHTTP(res, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, res.Code)
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+
+		return string(data)
+	}
+
+	t.Run("overrides", func(t *testing.T) {
+		templates, assets := t.TempDir(), t.TempDir()
+		writeFile(t, templates, templateIndex, `{{range .Ingresses}}{{.Label | upper}}:{{join "," .Tags}};{{end}}`)
+		writeFile(t, assets, "custom.css", `body {}`)
+
+		svc, err := NewWithOptions(Options{TemplatesDir: templates, AssetsDir: assets})
+		require.NoError(t, err)
+		svc.Set([]Ingress{
+			{UID: "grafana", Name: "grafana", Namespace: "monitoring"},
+			{UID: "prometheus", Name: "prometheus", Namespace: "monitoring", Tags: []string{"grafana-datasource"}},
+		})
+
+		require.Equal(t, "GRAFANA:;PROMETHEUS:grafana-datasource;", get(svc, "/"))
+		require.Contains(t, get(svc, "/details/grafana"), "mvp.css", "not overridden templates are embedded")
+		require.Equal(t, "body {}", get(svc, "/static/custom.css"))
+		require.NotEmpty(t, get(svc, "/static/mvp.css"))
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		templates := t.TempDir()
+		writeFile(t, templates, templateDetails, `{{if .Ingress}}`)
+		_, err := NewWithOptions(Options{TemplatesDir: templates})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), templateDetails)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		templates := t.TempDir()
+		writeFile(t, templates, templateAliases, `{{range .Links}}{{.Unknown}}{{end}}`)
+		_, err := NewWithOptions(Options{TemplatesDir: templates})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), templateAliases)
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		_, err := NewWithOptions(Options{AssetsDir: filepath.Join(t.TempDir(), "missing")})
+		require.Error(t, err)
+	})
+}
@@ -11,6 +11,7 @@ type CertInfo struct {
 	Expiration time.Time
 	Domains    []string
 	Issuer     string
+	Host       string // checked host
 }
 
 // Expiration time of TLS certificate.
@@ -66,5 +67,6 @@ func Expiration(ctx context.Context, host string) (CertInfo, error) {
 		Expiration: min,
 		Domains:    domains,
 		Issuer:     issuer,
+		Host:       host,
 	}, nil
 }
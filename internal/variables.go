@@ -0,0 +1,113 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var (
+	errUndefinedVariable = errors.New("undefined variable")
+	errInvalidVariable   = errors.New("invalid variable name")
+	errUnclosedVariable  = errors.New("unclosed variable")
+)
+
+var variableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// expandVariables replaces placeholders ${NAME} or ${NAME:-default} in content.
+// Values are looked up in vars first and then in environment variables.
+// Placeholder could be escaped by double dollar sign ($${NAME} becomes ${NAME}).
+// Undefined variables without default value cause an error.
+//
+// Placeholders are expanded in all lines, including lines started by # (YAML comments or, for example, Markdown
+// headings in block scalars). In such lines placeholders which can not be expanded are kept as-is instead of error,
+// so commented-out definitions may refer to undefined variables.
+func expandVariables(content []byte, vars map[string]string) ([]byte, error) {
+	var out bytes.Buffer
+	out.Grow(len(content))
+	line := 1
+	var lenient []byte // rest of current line if it is started by #
+	for i := 0; i < len(content); i++ {
+		if i == 0 || content[i-1] == '\n' {
+			lenient = commentLine(content[i:])
+		}
+		c := content[i]
+		if c == '\n' {
+			line++
+		}
+		if c != '$' {
+			out.WriteByte(c)
+
+			continue
+		}
+		rest := content[i:]
+		if bytes.HasPrefix(rest, []byte("$${")) {
+			out.WriteString("${")
+			i += 2
+
+			continue
+		}
+		if !bytes.HasPrefix(rest, []byte("${")) {
+			out.WriteByte(c)
+
+			continue
+		}
+		end := bytes.IndexByte(rest, '}')
+		if lenient != nil && (end < 0 || bytes.IndexByte(rest[:end], '\n') >= 0) {
+			out.WriteByte(c) // not closed in the same line
+
+			continue
+		}
+		if end < 0 {
+			return nil, fmt.Errorf("line %d: %w", line, errUnclosedVariable)
+		}
+		value, err := lookupVariable(string(rest[2:end]), vars)
+		if err != nil && lenient != nil {
+			value = string(rest[:end+1])
+		} else if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		out.WriteString(value)
+		line += bytes.Count(rest[:end], []byte("\n"))
+		i += end
+	}
+
+	return out.Bytes(), nil
+}
+
+// commentLine returns line (without line break) if it is started by #, otherwise nil.
+func commentLine(content []byte) []byte {
+	line := content
+	if end := bytes.IndexByte(content, '\n'); end >= 0 {
+		line = content[:end]
+	}
+	if !bytes.HasPrefix(bytes.TrimLeft(line, " \t"), []byte("#")) {
+		return nil
+	}
+
+	return line
+}
+
+func lookupVariable(expression string, vars map[string]string) (string, error) {
+	name, defaultValue, hasDefault := expression, "", false
+	if idx := strings.Index(expression, ":-"); idx >= 0 {
+		name, defaultValue, hasDefault = expression[:idx], expression[idx+2:], true
+	}
+	if !variableName.MatchString(name) {
+		return "", fmt.Errorf("%w %q", errInvalidVariable, name)
+	}
+	if value, ok := vars[name]; ok {
+		return value, nil
+	}
+	if value, ok := os.LookupEnv(name); ok {
+		return value, nil
+	}
+	if hasDefault {
+		return defaultValue, nil
+	}
+
+	return "", fmt.Errorf("%w %s", errUndefinedVariable, name)
+}
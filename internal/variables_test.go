@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandVariables(t *testing.T) {
+	t.Setenv("INGRESS_DASHBOARD_TEST_ENV", "from-env")
+
+	out, err := expandVariables([]byte("a: ${NAME}\nb: ${MISSING:-fallback}\nc: $${NAME}\nd: ${INGRESS_DASHBOARD_TEST_ENV}\ne: $5"), map[string]string{
+		"NAME": "value",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "a: value\nb: fallback\nc: ${NAME}\nd: from-env\ne: $5", string(out))
+
+	_, err = expandVariables([]byte("a: b\nc: ${MISSING}"), nil)
+	require.ErrorIs(t, err, errUndefinedVariable)
+	require.Contains(t, err.Error(), "line 2")
+
+	_, err = expandVariables([]byte("a: ${MISSING:-multi\nline}\nb: ${MISSING}"), nil)
+	require.ErrorIs(t, err, errUndefinedVariable)
+	require.Contains(t, err.Error(), "line 3")
+
+	_, err = expandVariables([]byte("${NAME"), nil)
+	require.ErrorIs(t, err, errUnclosedVariable)
+
+	_, err = expandVariables([]byte("${1NAME}"), nil)
+	require.ErrorIs(t, err, errInvalidVariable)
+}
+
+func TestExpandVariables_comments(t *testing.T) {
+	out, err := expandVariables([]byte("# url: ${OLD_HOST}\n  # ${OLD_HOST} ${HOST} ${bad name} ${\nurl: ${HOST} # ${HOST}\n#"), map[string]string{
+		"HOST": "example.com",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "# url: ${OLD_HOST}\n  # ${OLD_HOST} example.com ${bad name} ${\nurl: example.com # example.com\n#", string(out))
+
+	// Markdown heading in block scalar is not a comment, but looks the same
+	out, err = expandVariables([]byte("description: |\n  # ${TEAM} services\n  Owned by ${TEAM}"), map[string]string{
+		"TEAM": "SRE",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "description: |\n  # SRE services\n  Owned by SRE", string(out))
+
+	_, err = expandVariables([]byte("# comment\nurl: ${HOST}"), nil)
+	require.ErrorIs(t, err, errUndefinedVariable)
+	require.Contains(t, err.Error(), "line 2")
+}
@@ -0,0 +1,114 @@
+package internal
+
+import (
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	refreshParam   = "refresh"
+	defaultRefresh = 30   // seconds
+	minRefresh     = 5    // seconds, protects cluster from too often polling
+	maxRefresh     = 3600 // seconds
+)
+
+// Health states of wall tiles.
+const (
+	TileHealthy  = "healthy"
+	TileWarning  = "warning"
+	TileCritical = "critical"
+)
+
+// UITile is entry on wall display.
+type UITile struct {
+	Ingress  Ingress
+	State    string    // healthy, warning or critical
+	Problems []Problem // warnings and critical problems, the worst first
+}
+
+// UIWallContext is context of wall display. There is no user and search: wall is shown on shared screens.
+type UIWallContext struct {
+	UIContext
+	Tiles   []UITile
+	Health  NamespaceHealth // aggregated state of shown entries
+	Refresh int             // page reload interval in seconds
+}
+
+// wallTiles of entries: the worst first, then by namespace and label. Info problems are ignored.
+func wallTiles(list []Ingress) []UITile {
+	var ans = make([]UITile, 0, len(list))
+	for _, ing := range list {
+		tile := UITile{Ingress: ing, State: TileHealthy}
+		for _, problem := range problemsOf(ing) {
+			if problem.Severity >= SeverityWarning {
+				tile.Problems = append(tile.Problems, problem)
+			}
+		}
+		if len(tile.Problems) > 0 {
+			tile.State = TileWarning
+			if tile.Problems[0].Severity == SeverityCritical {
+				tile.State = TileCritical
+			}
+		}
+		ans = append(ans, tile)
+	}
+	rank := map[string]int{TileCritical: 0, TileWarning: 1, TileHealthy: 2} //nolint:gomnd
+	sort.SliceStable(ans, func(i, j int) bool {
+		a, b := ans[i], ans[j]
+		if a.State != b.State {
+			return rank[a.State] < rank[b.State]
+		}
+		if a.Ingress.Namespace != b.Ingress.Namespace {
+			return a.Ingress.Namespace < b.Ingress.Namespace
+		}
+
+		return a.Ingress.Label() < b.Ingress.Label()
+	})
+
+	return ans
+}
+
+// parseRefresh interval in seconds. Invalid or missing value means default, others are clamped.
+func parseRefresh(value string) int {
+	seconds, err := strconv.Atoi(value)
+	switch {
+	case err != nil || seconds <= 0:
+		return defaultRefresh
+	case seconds < minRefresh:
+		return minRefresh
+	case seconds > maxRefresh:
+		return maxRefresh
+	default:
+		return seconds
+	}
+}
+
+// getWall renders wall display. Entries are filtered by the same query parameters as index page (namespace, tag, ...).
+// User is intentionally not shown: page could be opened by access token.
+func (svc *Service) getWall(writer http.ResponseWriter, request *http.Request, _ httprouter.Params) {
+	locale := svc.locale(writer, request)
+	query := request.URL.Query()
+	list := ParseFilter(query).Apply(locale.localize(visibleIngresses(svc.getList())))
+
+	writer.Header().Set("Content-Type", "text/html")
+	writer.Header().Set("Cache-Control", "no-store")
+	if err := svc.wall.Execute(writer, UIWallContext{
+		UIContext: UIContext{
+			Ingresses: list,
+			Loading:   svc.health.Loading(),
+			Problems:  countProblems(list),
+			Branding:  svc.branding,
+			Locale:    locale,
+			Locales:   svc.locales.list,
+		},
+		Tiles:   wallTiles(list),
+		Health:  namespaceHealth(list),
+		Refresh: parseRefresh(query.Get(refreshParam)),
+	}); err != nil {
+		log.Println("failed render wall page:", err)
+	}
+}
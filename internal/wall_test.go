@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWallTiles(t *testing.T) {
+	tiles := wallTiles([]Ingress{
+		{UID: "docs", Static: true},
+		{UID: "grafana", Class: "nginx", Refs: []Ref{{URL: "https://grafana.example.com", Pods: 1}}},
+		{UID: "prometheus", Class: "traefik", Refs: []Ref{{URL: "http://prometheus.example.com"}}},
+		{UID: "legacy", Refs: []Ref{{URL: "http://legacy.example.com", Pods: 1}}},
+	})
+	var order, states []string
+	for _, tile := range tiles {
+		order = append(order, tile.Ingress.UID)
+		states = append(states, tile.State)
+	}
+	require.Equal(t, []string{"prometheus", "legacy", "docs", "grafana"}, order)
+	require.Equal(t, []string{TileCritical, TileWarning, TileHealthy, TileHealthy}, states)
+	require.Equal(t, ProblemDeadRefs, tiles[0].Problems[0].Kind)
+	require.Equal(t, ProblemNoClass, tiles[1].Problems[0].Kind)
+}
+
+func TestParseRefresh(t *testing.T) {
+	require.Equal(t, defaultRefresh, parseRefresh(""))
+	require.Equal(t, defaultRefresh, parseRefresh("abc"))
+	require.Equal(t, minRefresh, parseRefresh("1"))
+	require.Equal(t, 60, parseRefresh("60"))
+	require.Equal(t, maxRefresh, parseRefresh("86400"))
+}
+
+func TestService_wall(t *testing.T) {
+	svc := New()
+	svc.Set([]Ingress{
+		{UID: "grafana-dev", Name: "grafana-dev", Namespace: "dev", Class: "nginx", Refs: []Ref{{URL: "http://grafana.dev.example.com", Pods: 1}}},
+		{UID: "grafana", Name: "grafana", Namespace: "monitoring", Class: "nginx", Refs: []Ref{{URL: "https://grafana.example.com", Pods: 1}}},
+		{UID: "prometheus", Name: "prometheus", Namespace: "monitoring", Class: "traefik", Refs: []Ref{{URL: "http://prometheus.example.com"}}},
+	})
+
+	res := httptest.NewRecorder()
+	svc.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/wall?namespace=monitoring&refresh=60", nil))
+	require.Equal(t, http.StatusOK, res.Code)
+	require.Equal(t, "no-store", res.Header().Get("Cache-Control"))
+	body := res.Body.String()
+	require.Contains(t, body, `<meta http-equiv="refresh" content="60">`)
+	require.Contains(t, body, `<div class="tile critical">`)
+	require.Contains(t, body, `<h2>prometheus</h2>`)
+	require.Contains(t, body, `<h2>grafana</h2>`)
+	require.NotContains(t, body, `<h2>grafana-dev</h2>`)
+	require.NotContains(t, body, `static/mvp.css`)
+}